@@ -0,0 +1,51 @@
+package table
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Append adds the rows of other, with their descriptions and tree depths, to
+// the end of t. The headers of both tables must match. Configuration,
+// including alignments and widths, stays that of t; other's row-specific
+// settings (highlights, row colors, cell alignments) are not carried over.
+// Rows are added as by AddRow, so t's hash columns are computed for them.
+func (t *Table) Append(other *Table) error {
+	other.mu.RLock()
+	headers := slices.Clone(other.Headers)
+	rows := make([][]string, len(other.Rows))
+	for i, row := range other.Rows {
+		rows[i] = slices.Clone(row)
+	}
+	descs := cloneSliceMap(other.Descriptions)
+	titles := cloneSliceMap(other.DescriptionTitles)
+	styles := maps.Clone(other.descriptionStyles)
+	depths := maps.Clone(other.treeDepths)
+	other.mu.RUnlock()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !slices.Equal(t.Headers, headers) {
+		return fmt.Errorf("headers %q don't match %q", headers, t.Headers)
+	}
+
+	offset := len(t.Rows)
+	for _, row := range rows {
+		t.addRow(row)
+	}
+	for ri, d := range descs {
+		t.Descriptions[ri+offset] = d
+	}
+	for ri, d := range titles {
+		t.DescriptionTitles[ri+offset] = d
+	}
+	for key, style := range styles {
+		t.descriptionStyles[[2]int{key[0] + offset, key[1]}] = style
+	}
+	for ri, d := range depths {
+		t.treeDepths[ri+offset] = d
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package table
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// minBarLength is the number of bar cells a bar column reserves next to its
+// widest label
+const minBarLength = 10
+
+// SetBarColumn renders the numeric cells of col as horizontal bars followed by
+// their value, with a bar filling the space beside the widest label at
+// max and scaling proportionally below it. Without ANSI support the bar is
+// drawn with "#". Non-numeric cells are shown as is.
+func (t *Table) SetBarColumn(col int, max float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if col < 0 || col >= len(t.Headers) || max <= 0 {
+		return
+	}
+	t.barColumns[col] = max
+}
+
+// barValue parses a cell's visible content as a bar value
+func barValue(s string) (float64, bool) {
+	s = strings.ReplaceAll(strings.TrimSpace(stripANSI(s)), ",", "")
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	return v, err == nil
+}
+
+// detectBarLabelWidths records the widest numeric label of each bar column
+func (t *Table) detectBarLabelWidths() {
+	t.barLabelWidths = make(map[int]int, len(t.barColumns))
+	for ci := range t.barColumns {
+		widest := 0
+		for ri, row := range t.Rows {
+			if ci >= len(row) {
+				continue
+			}
+			label := stripANSI(t.cellDisplayValue(ri, ci, row[ci]))
+			if _, ok := barValue(label); ok {
+				if w := displayWidth(label); w > widest {
+					widest = w
+				}
+			}
+		}
+		t.barLabelWidths[ci] = widest
+	}
+}
+
+// barCell turns a displayed cell of a bar column into its bar and label,
+// sized to the column's rendered width
+func (t *Table) barCell(col int, cell string) string {
+	max, ok := t.barColumns[col]
+	if !ok {
+		return cell
+	}
+	v, ok := barValue(cell)
+	if !ok {
+		return cell
+	}
+	space := t.columnWidths[col] - t.barLabelWidths[col] - 1
+	if space < 1 {
+		return cell
+	}
+	filled := int(math.Round(math.Max(0, math.Min(1, v/max)) * float64(space)))
+	block := "█"
+	if !t.supportANSI || t.asciiGrid() {
+		block = "#"
+	}
+	return strings.Repeat(block, filled) + strings.Repeat(" ", space-filled) + " " + cell
+}
@@ -0,0 +1,114 @@
+package table
+
+// BorderChars are the glyphs a table's grid is drawn with
+type BorderChars struct {
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+	HLine       string
+	VLine       string
+	LeftT       string
+	RightT      string
+	TopT        string
+	BottomT     string
+	Cross       string
+}
+
+// UnicodeBorderChars draws the grid with box drawing characters (the default
+// under a UTF-8 locale)
+var UnicodeBorderChars = BorderChars{
+	TopLeft: TopLeft, TopRight: TopRight, BottomLeft: BottomLeft, BottomRight: BottomRight,
+	HLine: HLine, VLine: VLine,
+	LeftT: LeftT, RightT: RightT, TopT: TopT, BottomT: BottomT, Cross: Cross,
+}
+
+// ASCIIBorderChars draws the grid with "+", "-" and "|" only
+var ASCIIBorderChars = BorderChars{
+	TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+	HLine: "-", VLine: "|",
+	LeftT: "+", RightT: "+", TopT: "+", BottomT: "+", Cross: "+",
+}
+
+// asciiGlyphs replace the remaining non-ASCII border glyphs (styled
+// separators and header bars) when the grid is drawn in ASCII
+var asciiGlyphs = map[string]string{
+	"╞": "+", "═": "=", "╪": "+", "╤": "+", "╡": "+",
+	"┝": "+", "━": "=", "┿": "+", "┯": "+", "┥": "+",
+	"█": "#",
+}
+
+// SetBorderChars sets the glyphs the grid is drawn with, each of which must
+// be a single column wide. Empty fields fall back to the box drawing defaults.
+func (t *Table) SetBorderChars(chars BorderChars) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.borderChars = chars
+}
+
+// SetASCIIMode draws the grid with ASCIIBorderChars when enabled and with box
+// drawing characters otherwise, regardless of ANSI support and overriding the
+// locale-based default. Unlike SetBorderless, the grid is still drawn.
+func (t *Table) SetASCIIMode(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.borderChars = UnicodeBorderChars
+	if enabled {
+		t.borderChars = ASCIIBorderChars
+	}
+}
+
+// asciiGrid checks if every glyph of the table's border set is plain ASCII
+func (t *Table) asciiGrid() bool {
+	for _, char := range []string{
+		TopLeft, TopRight, BottomLeft, BottomRight, HLine, VLine,
+		LeftT, RightT, TopT, BottomT, Cross,
+	} {
+		g := t.glyph(char)
+		for i := 0; i < len(g); i++ {
+			if g[i] >= 0x80 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// glyph maps a box drawing character to the one of the table's border set
+func (t *Table) glyph(char string) string {
+	var g string
+	switch char {
+	case TopLeft:
+		g = t.borderChars.TopLeft
+	case TopRight:
+		g = t.borderChars.TopRight
+	case BottomLeft:
+		g = t.borderChars.BottomLeft
+	case BottomRight:
+		g = t.borderChars.BottomRight
+	case HLine:
+		g = t.borderChars.HLine
+	case VLine:
+		g = t.borderChars.VLine
+	case LeftT:
+		g = t.borderChars.LeftT
+	case RightT:
+		g = t.borderChars.RightT
+	case TopT:
+		g = t.borderChars.TopT
+	case BottomT:
+		g = t.borderChars.BottomT
+	case Cross:
+		g = t.borderChars.Cross
+	default:
+		if a, ok := asciiGlyphs[char]; ok && t.asciiGrid() {
+			return a
+		}
+	}
+	if g == "" {
+		return char
+	}
+	return g
+}
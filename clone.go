@@ -0,0 +1,113 @@
+package table
+
+import (
+	"maps"
+	"slices"
+)
+
+// Clone returns a deep copy of the table that can be modified and rendered
+// independently of t. The copy does not belong to t's table group.
+// Formatter and predicate functions and collators are shared, not copied.
+func (t *Table) Clone() *Table {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	c := t.clone()
+	c.visibleCache = maps.Clone(t.visibleCache)
+	return c
+}
+
+// clone implements Clone for callers already holding the lock. The copy
+// starts with an empty cache of measured cells, filled as it is rendered:
+// copying the cache can cost more than measuring the cells again.
+func (t *Table) clone() *Table {
+	c := *t
+	c.group = nil
+	c.mu = new(tableMutex)
+	c.prepared = nil
+	c.layoutInputs = nil
+	c.visibleCache = make(map[string]visibleText)
+
+	c.Headers = slices.Clone(t.Headers)
+	c.Rows = make([][]string, len(t.Rows))
+	for i, row := range t.Rows {
+		c.Rows[i] = slices.Clone(row)
+	}
+	c.Descriptions = cloneSliceMap(t.Descriptions)
+	c.DescriptionTitles = cloneSliceMap(t.DescriptionTitles)
+
+	c.columnWidths = slices.Clone(t.columnWidths)
+	c.alignments = slices.Clone(t.alignments)
+	c.highlightedHeaders = slices.Clone(t.highlightedHeaders)
+	c.wrapDelimiters = slices.Clone(t.wrapDelimiters)
+	c.headerGroups = slices.Clone(t.headerGroups)
+	c.footnotes = slices.Clone(t.footnotes)
+
+	c.maxWidths = maps.Clone(t.maxWidths)
+	c.minWidths = maps.Clone(t.minWidths)
+	c.highlightedCells = maps.Clone(t.highlightedCells)
+	c.highlightedRows = maps.Clone(t.highlightedRows)
+	c.rowColors = maps.Clone(t.rowColors)
+	c.columnColors = maps.Clone(t.columnColors)
+	c.columnSuffixes = maps.Clone(t.columnSuffixes)
+	c.cellAlignments = maps.Clone(t.cellAlignments)
+	c.fixedWidths = maps.Clone(t.fixedWidths)
+	c.widthPercents = maps.Clone(t.widthPercents)
+	c.autoCaption = maps.Clone(t.autoCaption)
+	c.explicitAlignments = maps.Clone(t.explicitAlignments)
+	c.numericColumns = maps.Clone(t.numericColumns)
+	c.columnFormatters = maps.Clone(t.columnFormatters)
+	c.decimalColumns = maps.Clone(t.decimalColumns)
+	c.decimalFracWidths = maps.Clone(t.decimalFracWidths)
+	c.decimalUnitWidths = maps.Clone(t.decimalUnitWidths)
+	c.barColumns = maps.Clone(t.barColumns)
+	c.barLabelWidths = maps.Clone(t.barLabelWidths)
+	c.separatorsBefore = maps.Clone(t.separatorsBefore)
+	c.headerBars = maps.Clone(t.headerBars)
+	c.collators = maps.Clone(t.collators)
+	c.colorLegend = maps.Clone(t.colorLegend)
+	c.flexColumns = maps.Clone(t.flexColumns)
+	c.protectedColumns = maps.Clone(t.protectedColumns)
+	c.hashColumns = cloneSliceMap(t.hashColumns)
+	c.descriptionStyles = maps.Clone(t.descriptionStyles)
+	c.treeDepths = maps.Clone(t.treeDepths)
+	c.treePrefixes = slices.Clone(t.treePrefixes)
+
+	if t.abbreviations != nil {
+		c.abbreviations = make(map[int]map[string]string, len(t.abbreviations))
+		for col, abbr := range t.abbreviations {
+			c.abbreviations[col] = maps.Clone(abbr)
+		}
+	}
+	return &c
+}
+
+// copyDisplaySettings carries the border, ANSI and wrapping settings of t,
+// which don't depend on its columns or rows, over to a table derived from it
+func (t *Table) copyDisplaySettings(dst *Table) {
+	dst.supportANSI = t.supportANSI
+	dst.dimBorder = t.dimBorder
+	dst.highlightHeaders = t.highlightHeaders
+	dst.borderless = t.borderless
+	dst.borderChars = t.borderChars
+	dst.consoleWidth = t.consoleWidth
+	dst.overflow = t.overflow
+	dst.wrapMode = t.wrapMode
+	dst.hyphenation = t.hyphenation
+	dst.wrapDelimiters = t.wrapDelimiters
+	dst.cellPadding = t.cellPadding
+	dst.tabWidth = t.tabWidth
+	dst.defaultMaxWidth = t.defaultMaxWidth
+}
+
+// cloneSliceMap copies a map of slices, copying the slices as well
+func cloneSliceMap[T any](m map[int][]T) map[int][]T {
+	if m == nil {
+		return nil
+	}
+	c := make(map[int][]T, len(m))
+	for k, v := range m {
+		c[k] = slices.Clone(v)
+	}
+	return c
+}
@@ -0,0 +1,200 @@
+package table
+
+import "fmt"
+
+// RenderColumns renders only the given columns, in the given order, with
+// widths computed over just those columns. Rows and their descriptions are
+// rendered as usual; header groups whose columns don't stay adjacent are
+// dropped, and a SetDimRowIf predicate sees omitted columns as empty.
+func (t *Table) RenderColumns(cols []int) (string, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if err := t.validateColumns(cols); err != nil {
+		return "", err
+	}
+	c := t.clone()
+	c.projectColumns(cols)
+	return c.Render(), nil
+}
+
+// ReorderColumns rearranges the columns so that column i is the one that was
+// at order[i], moving headers, cells and per-column settings with them.
+// order must be a permutation of the column indices. Descriptions are keyed
+// by row and unaffected.
+func (t *Table) ReorderColumns(order []int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(order) != len(t.Headers) {
+		return fmt.Errorf("got %d columns in order for %d columns", len(order), len(t.Headers))
+	}
+	if err := t.validateColumns(order); err != nil {
+		return err
+	}
+	t.projectColumns(order)
+	return nil
+}
+
+// validateColumns checks that cols is a non-empty list of distinct column
+// indices
+func (t *Table) validateColumns(cols []int) error {
+	if len(cols) == 0 {
+		return fmt.Errorf("no columns given")
+	}
+	seen := make(map[int]bool, len(cols))
+	for _, col := range cols {
+		if col < 0 || col >= len(t.Headers) {
+			return fmt.Errorf("column %d out of range (0-%d)", col, len(t.Headers)-1)
+		}
+		if seen[col] {
+			return fmt.Errorf("column %d given more than once", col)
+		}
+		seen[col] = true
+	}
+	return nil
+}
+
+// projectColumns rearranges the table so that column i is original column
+// cols[i], dropping the columns not listed. cols must pass validateColumns.
+func (t *Table) projectColumns(cols []int) {
+	// index maps an original column to its new position
+	index := make(map[int]int, len(cols))
+	for i, col := range cols {
+		index[col] = i
+	}
+	sourceCount := len(t.Headers)
+
+	project := func(values []string) []string {
+		projected := make([]string, len(cols))
+		for i, col := range cols {
+			if col < len(values) {
+				projected[i] = values[col]
+			}
+		}
+		return projected
+	}
+
+	t.Headers = project(t.Headers)
+	for ri, row := range t.Rows {
+		t.Rows[ri] = project(row)
+	}
+	t.alignments = project(t.alignments)
+	if len(t.columnWidths) == sourceCount {
+		widths := make([]int, len(cols))
+		for i, col := range cols {
+			widths[i] = t.columnWidths[col]
+		}
+		t.columnWidths = widths
+	} else {
+		t.columnWidths = make([]int, len(cols))
+	}
+
+	highlighted := make([]int, 0, len(t.highlightedHeaders))
+	for _, col := range t.highlightedHeaders {
+		if i, ok := index[col]; ok {
+			highlighted = append(highlighted, i)
+		}
+	}
+	t.highlightedHeaders = highlighted
+
+	// Keep the header groups whose columns are still adjacent and in order
+	groups := t.headerGroups[:0:0]
+	for _, g := range t.headerGroups {
+		start, ok := index[g.Start]
+		for col := g.Start + 1; ok && col < g.Start+g.Span; col++ {
+			i, found := index[col]
+			ok = found && i == start+col-g.Start
+		}
+		if ok {
+			g.Start = start
+			groups = append(groups, g)
+		}
+	}
+	t.headerGroups = groups
+
+	t.explicitAlignments = remapColumns(t.explicitAlignments, index)
+	t.maxWidths = remapColumns(t.maxWidths, index)
+	t.minWidths = remapColumns(t.minWidths, index)
+	t.fixedWidths = remapColumns(t.fixedWidths, index)
+	t.widthPercents = remapColumns(t.widthPercents, index)
+	t.flexColumns = remapColumns(t.flexColumns, index)
+	t.protectedColumns = remapColumns(t.protectedColumns, index)
+	t.columnSuffixes = remapColumns(t.columnSuffixes, index)
+	t.abbreviations = remapColumns(t.abbreviations, index)
+	t.columnFormatters = remapColumns(t.columnFormatters, index)
+	t.decimalColumns = remapColumns(t.decimalColumns, index)
+	t.barColumns = remapColumns(t.barColumns, index)
+	t.columnColors = remapColumns(t.columnColors, index)
+	t.headerBars = remapColumns(t.headerBars, index)
+	t.collators = remapColumns(t.collators, index)
+	if i, ok := index[t.treeColumn]; ok {
+		t.treeColumn = i
+	} else {
+		t.treeColumn = -1
+	}
+
+	// A hash column missing any of its sources keeps the hashes already
+	// computed, but new rows can't be hashed the same way
+	hashColumns := make(map[int][]int, len(t.hashColumns))
+	for col, sources := range t.hashColumns {
+		i, ok := index[col]
+		remapped := make([]int, 0, len(sources))
+		for _, src := range sources {
+			if j, found := index[src]; found {
+				remapped = append(remapped, j)
+			} else {
+				ok = false
+			}
+		}
+		if ok {
+			hashColumns[i] = remapped
+		}
+	}
+	t.hashColumns = hashColumns
+
+	cellAlignments := make(map[[2]int]string, len(t.cellAlignments))
+	for cell, alignment := range t.cellAlignments {
+		if i, ok := index[cell[1]]; ok {
+			cellAlignments[[2]int{cell[0], i}] = alignment
+		}
+	}
+	t.cellAlignments = cellAlignments
+	highlightedCells := make(map[[2]int]bool, len(t.highlightedCells))
+	for cell := range t.highlightedCells {
+		if i, ok := index[cell[1]]; ok {
+			highlightedCells[[2]int{cell[0], i}] = true
+		}
+	}
+	t.highlightedCells = highlightedCells
+
+	if formatter := t.cellFormatter; formatter != nil {
+		t.cellFormatter = func(row, col int, value string) string {
+			return formatter(row, cols[col], value)
+		}
+	}
+	if dimRowIf := t.dimRowIf; dimRowIf != nil {
+		// Hand the predicate rows in their original layout
+		t.dimRowIf = func(row []string) bool {
+			source := make([]string, sourceCount)
+			for i, col := range cols {
+				if i < len(row) {
+					source[col] = row[i]
+				}
+			}
+			return dimRowIf(source)
+		}
+	}
+}
+
+// remapColumns returns a copy of a column-keyed map with every column moved
+// to its position in index, dropping the columns index doesn't contain
+func remapColumns[V any](m map[int]V, index map[int]int) map[int]V {
+	remapped := make(map[int]V, len(m))
+	for col, v := range m {
+		if i, ok := index[col]; ok {
+			remapped[i] = v
+		}
+	}
+	return remapped
+}
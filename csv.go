@@ -0,0 +1,76 @@
+package table
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+// FromRecords creates a table with the given headers and adds each record as
+// a row. Short records are padded like AddRow.
+func FromRecords(headers []string, records [][]string) *Table {
+	t := NewTable(headers)
+	for _, record := range records {
+		t.AddRow(record)
+	}
+	return t
+}
+
+// FromCSV reads CSV from r using encoding/csv, treating the first record as
+// headers and the remaining records as rows. Records are read one at a time;
+// quoted fields may contain commas and newlines. Short records are padded and
+// long records truncated to the header count, like AddRow. Errors from the
+// CSV reader are returned as-is; input without a header record is an error.
+func FromCSV(r io.Reader) (*Table, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1 // Records may differ in length from the header
+
+	headers, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("no header record")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t := NewTable(headers)
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return t, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		t.AddRow(record)
+	}
+}
+
+// RenderCSV renders the headers and rows as CSV with ANSI codes stripped,
+// suitable for reading back with FromCSV
+func (t *Table) RenderCSV() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var sb strings.Builder
+	cw := csv.NewWriter(&sb)
+
+	write := func(row []string) {
+		record := make([]string, len(t.Headers))
+		for i := range record {
+			if i < len(row) {
+				record[i] = stripANSI(row[i])
+			}
+		}
+		// Writing to a strings.Builder cannot fail
+		_ = cw.Write(record)
+	}
+
+	write(t.Headers)
+	for _, row := range t.Rows {
+		write(row)
+	}
+	cw.Flush()
+	return sb.String()
+}
@@ -0,0 +1,109 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ANSI colors marking the rows and cells of a Diff
+const (
+	diffAddedColor    = "\x1b[32m"
+	diffRemovedColor  = "\x1b[31m"
+	diffModifiedColor = "\x1b[33m"
+)
+
+// Diff compares two tables with the same headers, matching rows by their
+// first column, and returns a table of newTable's rows followed by the rows
+// only oldTable has. Added rows are colored green, removed rows red, and
+// cells whose value changed yellow. Without ANSI support (as set for
+// newTable), the first cell of added and removed rows is prefixed with "+ " and
+// "- ", and changed cells with "~ ", instead.
+func Diff(oldTable, newTable *Table) (*Table, error) {
+	oldTable.mu.RLock()
+	oldHeaders := slices.Clone(oldTable.Headers)
+	oldRows := make([][]string, len(oldTable.Rows))
+	for i, row := range oldTable.Rows {
+		oldRows[i] = oldTable.fitRow(row)
+	}
+	oldTable.mu.RUnlock()
+
+	newTable.mu.RLock()
+	defer newTable.mu.RUnlock()
+
+	if !slices.Equal(oldHeaders, newTable.Headers) {
+		return nil, fmt.Errorf("headers %q don't match %q", newTable.Headers, oldHeaders)
+	}
+
+	d := NewTable(slices.Clone(newTable.Headers))
+	newTable.copyDisplaySettings(d)
+	d.alignments = slices.Clone(newTable.alignments)
+	for col := range newTable.explicitAlignments {
+		d.explicitAlignments[col] = true
+	}
+
+	key := func(row []string) string {
+		if len(row) == 0 {
+			return ""
+		}
+		return stripANSI(row[0])
+	}
+	// oldIndex maps a key to the first old row holding it
+	oldIndex := make(map[string]int, len(oldRows))
+	for ri, row := range oldRows {
+		if _, ok := oldIndex[key(row)]; !ok {
+			oldIndex[key(row)] = ri
+		}
+	}
+
+	mark := func(prefix, cell string) string {
+		if d.supportANSI {
+			return cell
+		}
+		return prefix + cell
+	}
+
+	modified := make(map[[2]int]bool)
+	matched := make(map[int]bool, len(oldRows))
+	for _, row := range newTable.Rows {
+		row = slices.Clone(newTable.fitRow(row))
+		oi, ok := oldIndex[key(row)]
+		if !ok || matched[oi] {
+			if len(row) > 0 {
+				row[0] = mark("+ ", row[0])
+			}
+			d.rowColors[len(d.Rows)] = diffAddedColor
+			d.addRow(row)
+			continue
+		}
+		matched[oi] = true
+		for ci, cell := range row {
+			if ci < len(oldRows[oi]) && stripANSI(oldRows[oi][ci]) == stripANSI(cell) {
+				continue
+			}
+			row[ci] = mark("~ ", cell)
+			modified[[2]int{len(d.Rows), ci}] = true
+		}
+		d.addRow(row)
+	}
+	for oi, row := range oldRows {
+		if matched[oi] {
+			continue
+		}
+		row = slices.Clone(row)
+		if len(row) > 0 {
+			row[0] = mark("- ", row[0])
+		}
+		d.rowColors[len(d.Rows)] = diffRemovedColor
+		d.addRow(row)
+	}
+
+	if len(modified) > 0 {
+		d.cellFormatter = func(row, col int, value string) string {
+			if modified[[2]int{row, col}] {
+				return diffModifiedColor + value + "\x1b[0m"
+			}
+			return value
+		}
+	}
+	return d, nil
+}
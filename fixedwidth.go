@@ -0,0 +1,39 @@
+package table
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// RenderFixedWidth renders the data rows as fixed-width records for legacy
+// fixed-format files: each field is padded with pad or truncated to exactly
+// widths[i] bytes, with no borders or separators. ANSI codes are stripped.
+// Truncation never splits a multi-byte character; the field is padded instead.
+// Columns beyond len(widths) are omitted.
+func (t *Table) RenderFixedWidth(widths []int, pad byte) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var sb strings.Builder
+	for _, row := range t.Rows {
+		for i, width := range widths {
+			field := ""
+			if i < len(row) {
+				field = stripANSI(row[i])
+			}
+			if len(field) > width {
+				cut := width
+				for cut > 0 && !utf8.RuneStart(field[cut]) {
+					cut--
+				}
+				field = field[:cut]
+			}
+			sb.WriteString(field)
+			for n := len(field); n < width; n++ {
+				sb.WriteByte(pad)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
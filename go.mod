@@ -4,6 +4,9 @@ go 1.23.0
 
 toolchain go1.24.2
 
-require golang.org/x/term v0.31.0
+require (
+	golang.org/x/term v0.31.0
+	golang.org/x/text v0.24.0
+)
 
 require golang.org/x/sys v0.32.0 // indirect
@@ -0,0 +1,38 @@
+package table
+
+import "strings"
+
+// graphvizRecordEscaper escapes characters that are special in Graphviz
+// record labels
+var graphvizRecordEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`|`, `\|`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`"`, `\"`,
+	"\n", `\n`,
+)
+
+// RenderGraphvizRecord renders the table as a Graphviz record label, e.g.
+// "{ { Name | Age } | { Alice | 30 } }". Each row, starting with the headers,
+// becomes a horizontal group of fields stacked vertically. ANSI codes are
+// stripped and record-special characters are escaped.
+func (t *Table) RenderGraphvizRecord() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	rows := append([][]string{t.Headers}, t.Rows...)
+	groups := make([]string, len(rows))
+	for ri, row := range rows {
+		fields := make([]string, len(t.Headers))
+		for ci := range fields {
+			if ci < len(row) {
+				fields[ci] = graphvizRecordEscaper.Replace(stripANSI(row[ci]))
+			}
+		}
+		groups[ri] = "{ " + strings.Join(fields, " | ") + " }"
+	}
+	return "{ " + strings.Join(groups, " | ") + " }"
+}
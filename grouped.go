@@ -0,0 +1,52 @@
+package table
+
+// RenderGrouped renders the rows sorted by the key column (see SortByColumn),
+// with a subtotal row after each run of rows sharing a key and a grand total
+// row at the end. aggregate builds a total row from the rows it covers, e.g.
+// a label in the key column and sums in the numeric columns. Total rows are
+// set off by a double separator and highlighted. An out-of-range key column
+// renders the table ungrouped.
+func (t *Table) RenderGrouped(keyCol int, aggregate func(rows [][]string) []string) string {
+	c := t.Clone()
+	if keyCol < 0 || keyCol >= len(c.Headers) {
+		return c.Render()
+	}
+	c.SortByColumn(keyCol, true)
+
+	key := func(row []string) string {
+		if keyCol < len(row) {
+			return stripANSI(row[keyCol])
+		}
+		return ""
+	}
+
+	rows := make([][]string, 0, len(c.Rows))
+	newIndex := make(map[int]int, len(c.Rows))
+	var totalRows []int
+	addTotal := func(group [][]string) {
+		totalRows = append(totalRows, len(rows))
+		rows = append(rows, c.fitRow(aggregate(group)))
+	}
+	for start := 0; start < len(c.Rows); {
+		end := start + 1
+		for end < len(c.Rows) && key(c.Rows[end]) == key(c.Rows[start]) {
+			end++
+		}
+		for ri := start; ri < end; ri++ {
+			newIndex[ri] = len(rows)
+			rows = append(rows, c.Rows[ri])
+		}
+		addTotal(c.Rows[start:end])
+		start = end
+	}
+	addTotal(c.Rows)
+
+	c.Rows = rows
+	c.remapRows(newIndex)
+	c.separatorsBefore = make(map[int]string, len(totalRows))
+	for _, ri := range totalRows {
+		c.separatorsBefore[ri] = "double"
+		c.highlightedRows[ri] = true
+	}
+	return c.Render()
+}
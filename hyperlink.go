@@ -0,0 +1,22 @@
+package table
+
+import "strings"
+
+// Hyperlink returns text wrapped in an OSC 8 hyperlink to url, which
+// supporting terminals render as a clickable link. The cell is measured and
+// wrapped by text alone, and without ANSI support only text is rendered.
+// Control characters are removed from url and text so they can't end the
+// sequence early.
+func Hyperlink(url, text string) string {
+	return "\x1b]8;;" + stripControl(url) + "\x1b\\" + stripControl(text) + "\x1b]8;;\x1b\\"
+}
+
+// stripControl removes ASCII control characters from s
+func stripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,107 @@
+package table
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// tableJSON is the serialized form of a table
+type tableJSON struct {
+	Title        string            `json:"title,omitempty"`
+	Headers      []string          `json:"headers"`
+	Rows         [][]string        `json:"rows"`
+	Alignments   []string          `json:"alignments,omitempty"`
+	Descriptions []descriptionJSON `json:"descriptions,omitempty"`
+}
+
+// descriptionJSON is a serialized row description
+type descriptionJSON struct {
+	Row       int    `json:"row"`
+	Title     string `json:"title,omitempty"`
+	Text      string `json:"text"`
+	Color     string `json:"color,omitempty"`
+	Alignment string `json:"alignment,omitempty"`
+}
+
+// MarshalJSON serializes the table's title, headers, rows, column alignments
+// and descriptions, with their styles. Descriptions are ordered by row. Other
+// configuration is not serialized.
+func (t *Table) MarshalJSON() ([]byte, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	out := tableJSON{
+		Title:      t.title,
+		Headers:    t.Headers,
+		Rows:       t.Rows,
+		Alignments: t.alignments,
+	}
+	rows := make([]int, 0, len(t.Descriptions))
+	for ri := range t.Descriptions {
+		rows = append(rows, ri)
+	}
+	sort.Ints(rows)
+	for _, ri := range rows {
+		titles := t.DescriptionTitles[ri]
+		for i, text := range t.Descriptions[ri] {
+			d := descriptionJSON{Row: ri, Text: text}
+			if i < len(titles) {
+				d.Title = titles[i]
+			}
+			if style, ok := t.descriptionStyles[[2]int{ri, i}]; ok {
+				d.Color, d.Alignment = style.color, style.alignment
+			}
+			out.Descriptions = append(out.Descriptions, d)
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON resets t to a new table, as created by NewTable, holding the
+// data serialized by MarshalJSON. t keeps its lock and its table group, so it
+// is safe to call while other goroutines use t.
+func (t *Table) UnmarshalJSON(data []byte) error {
+	var in tableJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	nt := NewTable(in.Headers)
+	nt.title = in.Title
+	for i, alignment := range in.Alignments {
+		if i < len(nt.alignments) && alignment != "left" {
+			nt.alignments[i] = alignment
+			nt.explicitAlignments[i] = true
+		}
+	}
+	for _, row := range in.Rows {
+		nt.addRow(row)
+	}
+	for _, d := range in.Descriptions {
+		if d.Row >= 0 && d.Row < len(nt.Rows) {
+			nt.Descriptions[d.Row] = append(nt.Descriptions[d.Row], d.Text)
+			nt.DescriptionTitles[d.Row] = append(nt.DescriptionTitles[d.Row], d.Title)
+			if d.Color != "" || d.Alignment == "right" || d.Alignment == "center" {
+				di := len(nt.Descriptions[d.Row]) - 1
+				style := descriptionStyle{color: d.Color, alignment: d.Alignment}
+				if style.alignment != "right" && style.alignment != "center" {
+					style.alignment = "left"
+				}
+				nt.descriptionStyles[[2]int{d.Row, di}] = style
+			}
+		}
+	}
+
+	// A zero Table, as allocated by json.Unmarshal, has no lock yet
+	if t.mu == nil {
+		*t = *nt
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	mu, group := t.mu, t.group
+	*t = *nt
+	t.mu, t.group = mu, group
+	return nil
+}
@@ -0,0 +1,73 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+)
+
+// latexEscaper escapes characters that are special in LaTeX text
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+	"\n", " ",
+)
+
+// RenderLaTeX renders the table as a LaTeX tabular environment, with a column
+// spec following the column alignments (l, r or c) and \hline rules around
+// the headers and rows. Descriptions become a row spanning all columns below
+// their data row. ANSI codes are stripped and special characters escaped.
+func (t *Table) RenderLaTeX() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	spec := make([]string, len(t.Headers))
+	for ci := range spec {
+		spec[ci] = "l"
+		if ci < len(t.alignments) {
+			switch t.alignments[ci] {
+			case "right":
+				spec[ci] = "r"
+			case "center":
+				spec[ci] = "c"
+			}
+		}
+	}
+
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		cells := make([]string, len(t.Headers))
+		for ci := range cells {
+			if ci < len(row) {
+				cells[ci] = latexEscaper.Replace(stripANSI(row[ci]))
+			}
+		}
+		sb.WriteString(strings.Join(cells, " & ") + ` \\` + "\n")
+	}
+
+	sb.WriteString(`\begin{tabular}{|` + strings.Join(spec, "|") + "|}\n")
+	sb.WriteString(`\hline` + "\n")
+	writeRow(t.Headers)
+	sb.WriteString(`\hline` + "\n")
+	for ri, row := range t.Rows {
+		writeRow(row)
+		titles := t.DescriptionTitles[ri]
+		for di, desc := range t.Descriptions[ri] {
+			text := latexEscaper.Replace(stripANSI(desc))
+			if di < len(titles) && titles[di] != "" {
+				text = `\textbf{` + latexEscaper.Replace(stripANSI(titles[di])) + `}: ` + text
+			}
+			sb.WriteString(`\multicolumn{` + strconv.Itoa(len(t.Headers)) + `}{|l|}{` + text + `} \\` + "\n")
+		}
+	}
+	sb.WriteString(`\hline` + "\n")
+	sb.WriteString(`\end{tabular}` + "\n")
+	return sb.String()
+}
@@ -0,0 +1,134 @@
+package table
+
+import (
+	"slices"
+	"sync"
+)
+
+// tableMutex guards a table and tracks whether its cached layout (column
+// widths and numeric columns) is still valid. Every mutator takes the lock
+// for writing, so taking it through Lock marks the layout stale; Render
+// takes it through lockForRender, which doesn't.
+type tableMutex struct {
+	sync.RWMutex
+	layoutValid bool
+}
+
+// Lock locks m for writing and marks the layout stale
+func (m *tableMutex) Lock() {
+	m.RWMutex.Lock()
+	m.layoutValid = false
+}
+
+// lockForRender locks m for writing, keeping the cached layout
+func (m *tableMutex) lockForRender() {
+	m.RWMutex.Lock()
+}
+
+// InvalidateLayout forces the next Render to recompute the layout. The
+// table's setters do this themselves, and Render notices changes made
+// directly to the exported Headers, Rows, Descriptions and
+// DescriptionTitles fields, so this is only needed to force a recompute.
+func (t *Table) InvalidateLayout() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.mu.layoutValid = false
+}
+
+// layoutInputs is a shallow copy of the exported fields the layout was
+// computed from, to tell whether they were changed directly since. Strings
+// are compared by value, which is quick for cells that weren't replaced.
+type layoutInputs struct {
+	headers           []string
+	rows              [][]string
+	descriptions      map[int][]string
+	descriptionTitles map[int][]string
+}
+
+// snapshotInputs records the exported fields the layout is computed from
+func (t *Table) snapshotInputs() *layoutInputs {
+	in := &layoutInputs{
+		headers:           slices.Clone(t.Headers),
+		rows:              make([][]string, len(t.Rows)),
+		descriptions:      cloneSliceMap(t.Descriptions),
+		descriptionTitles: cloneSliceMap(t.DescriptionTitles),
+	}
+	for i, row := range t.Rows {
+		in.rows[i] = slices.Clone(row)
+	}
+	return in
+}
+
+// inputsUnchanged reports whether the exported fields still hold what they
+// did when the layout was computed
+func (t *Table) inputsUnchanged() bool {
+	in := t.layoutInputs
+	if in == nil || !slices.Equal(in.headers, t.Headers) || len(in.rows) != len(t.Rows) {
+		return false
+	}
+	for i, row := range t.Rows {
+		if !slices.Equal(in.rows[i], row) {
+			return false
+		}
+	}
+	return sliceMapsEqual(in.descriptions, t.Descriptions) &&
+		sliceMapsEqual(in.descriptionTitles, t.DescriptionTitles)
+}
+
+// sliceMapsEqual reports whether a and b hold equal slices under the same keys
+func sliceMapsEqual(a, b map[int][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || !slices.Equal(v, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// layoutCached reports whether the layout computed last still holds
+func (t *Table) layoutCached() bool {
+	return t.mu.layoutValid && t.inputsUnchanged()
+}
+
+// cacheLayout marks the layout just computed as valid for the current
+// content
+func (t *Table) cacheLayout() {
+	t.layoutInputs = t.snapshotInputs()
+	t.mu.layoutValid = true
+}
+
+// layout computes the column widths and numeric columns, unless nothing has
+// changed since the last render
+func (t *Table) layout() {
+	if t.layoutCached() {
+		return
+	}
+	t.computeColumnWidths()
+	t.detectNumericColumns()
+	t.cacheLayout()
+}
+
+// preparedTable returns the copy of the table that is drawn in its place,
+// with duplicate rows collapsed first, so that row numbers count the
+// collapsed rows, then row numbers added. The copy built by the last render
+// is reused unless something has changed since.
+func (t *Table) preparedTable() *Table {
+	if t.prepared != nil && t.layoutCached() {
+		return t.prepared
+	}
+	p := t
+	if t.collapseDuplicates {
+		p = t.clone()
+		p.group = t.group
+		p.visibleCache = t.visibleCache // Only drawn under t's lock
+		p.collapseDuplicates = false
+		p.collapseRows()
+	}
+	t.prepared = p.prepareWithRowCount()
+	t.cacheLayout()
+	return t.prepared
+}
@@ -0,0 +1,44 @@
+package table
+
+// Option configures a Table created with NewTableWith
+type Option func(*Table)
+
+// NewTableWith creates a table like NewTable and applies the given options in
+// order, so later options override earlier ones
+func NewTableWith(headers []string, opts ...Option) *Table {
+	t := NewTable(headers)
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// WithConsoleWidth sets the maximum width for the table
+func WithConsoleWidth(width int) Option {
+	return func(t *Table) { t.SetConsoleWidth(width) }
+}
+
+// WithFillWidth sets whether the table should expand to fill the console width
+func WithFillWidth(enabled bool) Option {
+	return func(t *Table) { t.SetFillWidth(enabled) }
+}
+
+// WithBorderless enables/disables drawing of any box-drawing characters
+func WithBorderless(on bool) Option {
+	return func(t *Table) { t.SetBorderless(on) }
+}
+
+// WithDimBorder enables/disables dim border styling
+func WithDimBorder(enabled bool) Option {
+	return func(t *Table) { t.SetDimBorder(enabled) }
+}
+
+// WithAlignment sets the alignment for a specific column
+func WithAlignment(col int, alignment string) Option {
+	return func(t *Table) { t.SetAlignment(col, alignment) }
+}
+
+// WithANSISupport overrides terminal detection of ANSI support
+func WithANSISupport(enabled bool) Option {
+	return func(t *Table) { t.SetANSISupport(enabled) }
+}
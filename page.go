@@ -0,0 +1,121 @@
+package table
+
+import "fmt"
+
+// RenderPage renders the headers and the rows of one page, with their
+// descriptions, inside the usual borders. Pages are numbered from 1 and hold
+// pageSize rows; the last may hold fewer. Column widths are computed over the
+// whole table, so that they stay the same from page to page, and row numbers
+// count from the start of the table. A page past the last one is an error; an
+// empty table has a single, empty page.
+func (t *Table) RenderPage(page, pageSize int) (string, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if pageSize < 1 {
+		return "", fmt.Errorf("invalid page size %d", pageSize)
+	}
+	pages := max(1, (len(t.Rows)+pageSize-1)/pageSize)
+	if page < 1 || page > pages {
+		return "", fmt.Errorf("page %d out of range (1-%d)", page, pages)
+	}
+
+	// Lay out the whole table, numbered, then pin its widths on the page
+	full := t.clone().prepareWithRowCount()
+	full.maxDisplayRows = 0
+	full.treePrefixes = full.treeConnectors()
+	full.computeColumnWidths()
+
+	p := full.clone()
+	p.fixedWidths = make(map[int]int, len(full.columnWidths))
+	for col, width := range full.columnWidths {
+		p.fixedWidths[col] = width
+	}
+	p.widthPercents = make(map[int]float64)
+	start := (page - 1) * pageSize
+	p.keepRows(start, min(start+pageSize, len(t.Rows)))
+	return p.Render(), nil
+}
+
+// keepRows drops every data row outside [start, end), moving the row-keyed
+// settings of the rest with them
+func (t *Table) keepRows(start, end int) {
+	inRange := func(ri int) bool { return ri >= start && ri < end }
+
+	t.Rows = t.Rows[start:end]
+	descs := make(map[int][]string)
+	for ri, d := range t.Descriptions {
+		if inRange(ri) {
+			descs[ri-start] = d
+		}
+	}
+	titles := make(map[int][]string)
+	for ri, d := range t.DescriptionTitles {
+		if inRange(ri) {
+			titles[ri-start] = d
+		}
+	}
+	t.Descriptions, t.DescriptionTitles = descs, titles
+	styles := make(map[[2]int]descriptionStyle)
+	for key, style := range t.descriptionStyles {
+		if inRange(key[0]) {
+			styles[[2]int{key[0] - start, key[1]}] = style
+		}
+	}
+	t.descriptionStyles = styles
+	depths := make(map[int]int)
+	for ri, d := range t.treeDepths {
+		if inRange(ri) {
+			depths[ri-start] = d
+		}
+	}
+	t.treeDepths = depths
+
+	cellAlignments := make(map[[2]int]string)
+	for cell, alignment := range t.cellAlignments {
+		if inRange(cell[0]) {
+			cellAlignments[[2]int{cell[0] - start, cell[1]}] = alignment
+		}
+	}
+	t.cellAlignments = cellAlignments
+	highlightedCells := make(map[[2]int]bool)
+	for cell := range t.highlightedCells {
+		if inRange(cell[0]) {
+			highlightedCells[[2]int{cell[0] - start, cell[1]}] = true
+		}
+	}
+	t.highlightedCells = highlightedCells
+
+	highlightedRows := make(map[int]bool)
+	for ri := range t.highlightedRows {
+		if inRange(ri) {
+			highlightedRows[ri-start] = true
+		}
+	}
+	t.highlightedRows = highlightedRows
+	rowColors := make(map[int]string)
+	for ri, code := range t.rowColors {
+		if inRange(ri) {
+			rowColors[ri-start] = code
+		}
+	}
+	t.rowColors = rowColors
+	separators := make(map[int]string)
+	for ri, style := range t.separatorsBefore {
+		// The separator above the first row is the header separator
+		if ri == 0 || (ri > start && ri < end) {
+			separators[max(ri-start, 0)] = style
+		}
+	}
+	t.separatorsBefore = separators
+
+	// Keep the zebra stripes in step with the whole table
+	if start%2 == 1 {
+		t.zebraOddBG, t.zebraEvenBG = t.zebraEvenBG, t.zebraOddBG
+	}
+	if formatter := t.cellFormatter; formatter != nil {
+		t.cellFormatter = func(row, col int, value string) string {
+			return formatter(row+start, col, value)
+		}
+	}
+}
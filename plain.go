@@ -0,0 +1,97 @@
+package table
+
+import "strings"
+
+// plainGap separates the columns of RenderPlain
+const plainGap = "  "
+
+// RenderPlain renders the table as aligned text columns, like `column -t`:
+// columns are two spaces apart, with no border characters and no frame.
+// Headers are underlined with dashes unless SetHeaderSeparator hides the
+// separator. Cells are sized and wrapped as Render would, and descriptions
+// are indented under their row from the second column.
+func (t *Table) RenderPlain() string {
+	c := t.Clone().prepareWithRowCount()
+	if !c.supportANSI {
+		c.stripANSIContent()
+	}
+	c.cellPadding = 0
+	c.treePrefixes = c.treeConnectors()
+	c.computeColumnWidths()
+	c.detectNumericColumns()
+
+	var sb strings.Builder
+	if c.showHeader {
+		lines := make([][]string, len(c.Headers))
+		for ci, h := range c.Headers {
+			lines[ci] = c.smartSplitCellContent(h, ci)
+		}
+		sb.WriteString(c.plainLines(lines, -1, func(txt string, ci int) string {
+			return c.getHighlightedText(txt, ci)
+		}))
+		if c.headerSeparator {
+			rules := make([]string, len(c.columnWidths))
+			for ci, w := range c.columnWidths {
+				rules[ci] = strings.Repeat("-", w)
+			}
+			sb.WriteString(strings.Join(rules, plainGap) + "\n")
+		}
+	}
+
+	indent := 0
+	if first := c.descriptionColumn(); first > 0 {
+		indent = c.columnWidths[0] + len(plainGap)
+	}
+	width := max(c.totalWidth()-indent, 1)
+	for ri, row := range c.Rows {
+		row = c.fitRow(row)
+		lines := make([][]string, len(row))
+		for ci, cell := range row {
+			lines[ci] = c.smartSplitCellContent(c.barCell(ci, c.cellDisplayValue(ri, ci, cell)), ci)
+		}
+		sb.WriteString(c.plainLines(lines, ri, func(txt string, ci int) string {
+			return c.colorCell(txt, ri, ci)
+		}))
+
+		titles := c.DescriptionTitles[ri]
+		for di, desc := range c.rowDescriptions(ri) {
+			var text []string
+			if di < len(titles) && titles[di] != "" {
+				text = append(text, c.smartSplitByWords(titles[di]+":", width)...)
+			}
+			for _, bp := range strings.Split(desc, "\n") {
+				if bp = strings.TrimSpace(bp); bp != "" {
+					text = append(text, c.smartSplitByWords(bp, width)...)
+				}
+			}
+			for _, line := range text {
+				sb.WriteString(strings.Repeat(" ", indent) + line + "\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// plainLines lays out the wrapped cells of one row (ri -1 for the headers)
+// side by side, styling each line with style and trimming trailing space
+func (t *Table) plainLines(cells [][]string, ri int, style func(txt string, ci int) string) string {
+	height := 0
+	for _, lines := range cells {
+		height = max(height, len(lines))
+	}
+
+	var sb strings.Builder
+	fields := make([]string, len(cells))
+	for line := 0; line < height; line++ {
+		for ci, lines := range cells {
+			txt := ""
+			idx := line - t.verticalOffset(len(lines), height)
+			if idx >= 0 && idx < len(lines) {
+				txt = style(lines[idx], ci)
+			}
+			fields[ci] = t.formatCellContent(txt, ri, ci)
+		}
+		sb.WriteString(strings.TrimRight(strings.Join(fields, plainGap), " ") + "\n")
+	}
+	return sb.String()
+}
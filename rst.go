@@ -0,0 +1,25 @@
+package table
+
+// RenderGridRST renders the table as a reStructuredText grid table: an ASCII
+// grid with "+", "-" and "|", the header row underlined with "=" and every
+// data row closed by a rule. Cells are wrapped as Render would; descriptions
+// become cells spanning the columns they cover. ANSI codes are stripped, and
+// the title, footnotes, caption and legend, which RST grid tables can't hold,
+// are left out.
+func (t *Table) RenderGridRST() string {
+	c := t.Clone()
+	c.supportANSI = false
+	c.borderless = false
+	c.borderChars = ASCIIBorderChars
+	c.innerBorders = true
+	c.rowSeparators = true
+	c.headerSeparator = true
+	c.headerRepeat = 0
+	c.headerBars = nil
+	c.separatorsBefore = map[int]string{0: "double"}
+	c.title = ""
+	c.autoCaption = nil
+	c.footnotes = nil
+	c.colorLegend = nil
+	return c.Render()
+}
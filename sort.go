@@ -0,0 +1,147 @@
+package table
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/text/collate"
+)
+
+// SetCollator sets a locale-aware collator used by SortByColumn to compare
+// values in a column, so that e.g. "é" sorts next to "e" rather than after "z".
+// A nil collator restores plain byte-order comparison.
+func (t *Table) SetCollator(col int, c *collate.Collator) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if col < 0 || col >= len(t.Headers) {
+		return
+	}
+	if c == nil {
+		delete(t.collators, col)
+		return
+	}
+	t.collators[col] = c
+}
+
+// SortByColumn stably sorts the rows by the visible (ANSI-stripped) values of
+// a column, using the column's collator if one is set and byte order
+// otherwise. Descriptions, per-cell alignments, highlights, row colors, tree
+// depths and separator styles move with their rows; the separator above the
+// first row is the header separator and stays in place.
+func (t *Table) SortByColumn(col int, ascending bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if col < 0 || col >= len(t.Headers) {
+		return
+	}
+
+	compare := strings.Compare
+	if c, ok := t.collators[col]; ok {
+		compare = c.CompareString
+	}
+
+	value := func(ri int) string {
+		if col < len(t.Rows[ri]) {
+			return stripANSI(t.Rows[ri][col])
+		}
+		return ""
+	}
+
+	// order[i] is the original index of the row that ends up at position i
+	order := make([]int, len(t.Rows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		cmp := compare(value(order[a]), value(order[b]))
+		if ascending {
+			return cmp < 0
+		}
+		return cmp > 0
+	})
+
+	newIndex := make(map[int]int, len(order))
+	rows := make([][]string, len(order))
+	for i, ri := range order {
+		rows[i] = t.Rows[ri]
+		newIndex[ri] = i
+	}
+	t.Rows = rows
+
+	t.remapRows(newIndex)
+}
+
+// remapRows moves the row-keyed settings (descriptions, cell alignments,
+// highlights, row colors, tree depths and separator styles) of each row ri to
+// row newIndex[ri]. Rows missing from newIndex keep their settings where they
+// are. The separator above row 0 is the header separator, so it stays in
+// place, and a row moved to the top takes it instead of its own.
+func (t *Table) remapRows(newIndex map[int]int) {
+	moved := func(ri int) int {
+		if ni, ok := newIndex[ri]; ok {
+			return ni
+		}
+		return ri
+	}
+
+	descs := make(map[int][]string, len(t.Descriptions))
+	titles := make(map[int][]string, len(t.DescriptionTitles))
+	for ri, d := range t.Descriptions {
+		descs[moved(ri)] = d
+	}
+	for ri, d := range t.DescriptionTitles {
+		titles[moved(ri)] = d
+	}
+	t.Descriptions, t.DescriptionTitles = descs, titles
+
+	cellAlignments := make(map[[2]int]string, len(t.cellAlignments))
+	for cell, alignment := range t.cellAlignments {
+		cell[0] = moved(cell[0])
+		cellAlignments[cell] = alignment
+	}
+	t.cellAlignments = cellAlignments
+
+	treeDepths := make(map[int]int, len(t.treeDepths))
+	for ri, d := range t.treeDepths {
+		treeDepths[moved(ri)] = d
+	}
+	t.treeDepths = treeDepths
+
+	descriptionStyles := make(map[[2]int]descriptionStyle, len(t.descriptionStyles))
+	for key, style := range t.descriptionStyles {
+		key[0] = moved(key[0])
+		descriptionStyles[key] = style
+	}
+	t.descriptionStyles = descriptionStyles
+
+	highlightedCells := make(map[[2]int]bool, len(t.highlightedCells))
+	for cell := range t.highlightedCells {
+		cell[0] = moved(cell[0])
+		highlightedCells[cell] = true
+	}
+	t.highlightedCells = highlightedCells
+
+	highlightedRows := make(map[int]bool, len(t.highlightedRows))
+	for ri := range t.highlightedRows {
+		highlightedRows[moved(ri)] = true
+	}
+	t.highlightedRows = highlightedRows
+
+	rowColors := make(map[int]string, len(t.rowColors))
+	for ri, code := range t.rowColors {
+		rowColors[moved(ri)] = code
+	}
+	t.rowColors = rowColors
+
+	separators := make(map[int]string, len(t.separatorsBefore))
+	for ri, style := range t.separatorsBefore {
+		if ri == 0 {
+			separators[0] = style
+		} else if ni := moved(ri); ni != 0 {
+			separators[ni] = style
+		}
+	}
+	t.separatorsBefore = separators
+}
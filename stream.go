@@ -0,0 +1,98 @@
+package table
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// StreamRows renders rows as they arrive on ch instead of collecting them
+// first: the title, top border and headers are written to w straight away,
+// then each row as it is received, and the bottom border once ch is closed
+// or ctx is done. Column widths are computed up front from the headers and
+// any rows already added; fix them with SetColumnWidth or SetMaxWidth for
+// content that is not known yet. Streamed rows are not added to the table
+// and descriptions are not rendered. The stream is drawn from a copy of the
+// table taken when it starts, so the table stays usable while rows are
+// streamed and later changes don't affect the stream. It returns ctx's error
+// if it was cancelled, or the first write error.
+func (t *Table) StreamRows(ctx context.Context, ch <-chan []string, w io.Writer) error {
+	st, numbered, start := t.streamTable()
+
+	var sb strings.Builder
+	sb.WriteString(st.renderTitle())
+	if len(st.headerGroups) > 0 {
+		sb.WriteString(st.renderHeaderGroups())
+	} else {
+		sb.WriteString(st.renderTopBorder())
+	}
+	sb.WriteString(st.renderHeaderRows())
+	if st.showHeader && st.innerBorders && st.headerSeparator {
+		sb.WriteString(st.renderSeparatorBefore(0, false))
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return err
+	}
+
+	var streamErr error
+	for ri := 0; ; ri++ {
+		var row []string
+		var ok bool
+		select {
+		case <-ctx.Done():
+			streamErr = ctx.Err()
+		case row, ok = <-ch:
+		}
+		if streamErr != nil || !ok {
+			break
+		}
+
+		if numbered {
+			row = append([]string{strconv.Itoa(ri + start)}, row...)
+		}
+		row = st.fitRow(row)
+		if !st.supportANSI {
+			plain := make([]string, len(row))
+			for i, cell := range row {
+				plain[i] = stripANSI(cell)
+			}
+			row = plain
+		}
+
+		out := ""
+		if ri > 0 && st.hasSeparatorBefore(ri) {
+			out = st.renderSeparatorBefore(ri, false)
+		}
+		out += st.renderDataRow(ri, row)
+		if _, err := io.WriteString(w, out); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(w, st.renderBottomBorder()+st.renderFooter()); err != nil {
+		return err
+	}
+	return streamErr
+}
+
+// streamTable returns the copy of the table StreamRows draws with, its column
+// widths computed, and whether streamed rows are numbered and from what
+func (t *Table) streamTable() (st *Table, numbered bool, start int) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	c := t.clone()
+	c.group = t.group
+	st = c.prepareWithRowCount()
+	if !st.supportANSI {
+		st.dimBorder = false
+		st.highlightHeaders = false
+		st.title = stripANSI(st.title)
+		for i, h := range st.Headers {
+			st.Headers[i] = stripANSI(h)
+		}
+	}
+	st.computeColumnWidths()
+	return st, t.rowCountEnabled, t.rowCountStart
+}
@@ -0,0 +1,88 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// structColumn describes a struct field rendered as a column
+type structColumn struct {
+	index     int
+	header    string
+	omitEmpty bool
+}
+
+// FromStructs builds a table from a slice (or array) of structs or struct
+// pointers. Each exported field becomes a column named after the field, and
+// values are stringified with fmt.Sprint. A `table:"Header,omitempty"` tag
+// renames the column and renders zero values as empty cells; `table:"-"`
+// skips the field. Nil pointer elements produce empty rows.
+func FromStructs(v interface{}) (*Table, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("FromStructs: expected a slice of structs, got %T", v)
+	}
+
+	elemType := rv.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("FromStructs: expected a slice of structs, got %T", v)
+	}
+
+	columns := structColumns(elemType)
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.header
+	}
+	t := NewTable(headers)
+
+	for i := 0; i < rv.Len(); i++ {
+		elem := rv.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				t.AddRow(nil)
+				continue
+			}
+			elem = elem.Elem()
+		}
+
+		row := make([]string, len(columns))
+		for ci, col := range columns {
+			field := elem.Field(col.index)
+			if col.omitEmpty && field.IsZero() {
+				continue
+			}
+			row[ci] = fmt.Sprint(field.Interface())
+		}
+		t.AddRow(row)
+	}
+	return t, nil
+}
+
+// structColumns returns the columns for the exported fields of a struct type
+func structColumns(typ reflect.Type) []structColumn {
+	var columns []structColumn
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		col := structColumn{index: i, header: field.Name}
+		if tag, ok := field.Tag.Lookup("table"); ok {
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			if name != "" {
+				col.header = name
+			}
+			col.omitEmpty = opts == "omitempty"
+		}
+		columns = append(columns, col)
+	}
+	return columns
+}
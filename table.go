@@ -2,13 +2,23 @@
 package table
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
 	"golang.org/x/term"
+	"golang.org/x/text/collate"
 )
 
 const (
@@ -38,9 +48,78 @@ const (
 	// Cell sizing constants
 	minTerminalWidth = 80
 	maxColumnWidth   = 50
+
+	// defaultTabWidth is the interval of the tab stops tabs expand to
+	defaultTabWidth = 8
+	// defaultWrapDelimiter is the list delimiter WrapAuto wraps at
+	defaultWrapDelimiter = ","
+
+	// minDescriptionWidth is the narrowest text width of a description block
+	minDescriptionWidth = 10
+)
+
+// OverflowMode controls how cell content wider than its column is handled
+type OverflowMode int
+
+const (
+	// OverflowWrap wraps overlong content onto multiple lines (default)
+	OverflowWrap OverflowMode = iota
+	// OverflowTruncate cuts overlong content at the column width
+	OverflowTruncate
+	// OverflowEllipsis cuts overlong content and marks the cut with "…"
+	OverflowEllipsis
+)
+
+// WrapMode selects how overlong cell content is split into lines
+type WrapMode int
+
+const (
+	// WrapAuto splits comma lists at commas, paths at "/" and ".", and
+	// anything else at spaces (default)
+	WrapAuto WrapMode = iota
+	// WrapWord splits at spaces only
+	WrapWord
+	// WrapChar splits at exactly the column width, ignoring word boundaries
+	WrapChar
+	// WrapNone keeps content on a single line; combine with SetOverflow to clip it
+	WrapNone
+)
+
+// ShrinkStrategy selects how columns are narrowed when the table is wider
+// than the console
+type ShrinkStrategy int
+
+const (
+	// ShrinkWidest repeatedly narrows the widest column (default)
+	ShrinkWidest ShrinkStrategy = iota
+	// ShrinkProportional narrows every column in proportion to its width
+	ShrinkProportional
+)
+
+// ExpandStrategy selects how extra width is shared out in fillWidth mode
+type ExpandStrategy int
+
+const (
+	// ExpandEqual gives every expandable column the same extra width (default)
+	ExpandEqual ExpandStrategy = iota
+	// ExpandProportional gives each expandable column extra width in
+	// proportion to its content width
+	ExpandProportional
 )
 
-// Table represents a table with borders and alignment control
+// HeaderGroup is a label spanning several adjacent columns above the headers
+type HeaderGroup struct {
+	Label string
+	Start int // Index of the first spanned column
+	Span  int // Number of spanned columns
+}
+
+// Table represents a table with borders and alignment control.
+//
+// A Table is safe for concurrent use: the mutators and Render take its lock
+// exclusively, while read-only accessors such as RowCount share it. Direct
+// writes to the exported Headers, Rows, Descriptions and DescriptionTitles
+// fields are not synchronized, so they must not race with other use.
 type Table struct {
 	Headers            []string
 	Rows               [][]string
@@ -50,31 +129,258 @@ type Table struct {
 	alignments         []string // "left", "right", "center" for each column
 	consoleWidth       int      // Maximum width of the console
 	fillWidth          bool
-	maxWidths          map[int]int // Maximum width for specific columns
-	dimBorder          bool        // New field
-	supportANSI        bool        // Support for ANSI codes
-	borderless         bool        // Flag to disable borders
-	highlightHeaders   bool        // Always highlight headers
-	highlightedHeaders []int       // Indices of headers to highlight
-	rowCountEnabled    bool        // Flag to enable row count
+	maxWidths          map[int]int                             // Maximum width for specific columns
+	minWidths          map[int]int                             // Widths specific columns are never shrunk below
+	defaultMaxWidth    int                                     // Cap for every column (<= 0 = no cap)
+	dimBorder          bool                                    // New field
+	supportANSI        bool                                    // Support for ANSI codes
+	borderless         bool                                    // Flag to disable borders
+	highlightHeaders   bool                                    // Always highlight headers
+	highlightedHeaders []int                                   // Indices of headers to highlight
+	highlightedCells   map[[2]int]bool                         // (row, col) data cells to highlight
+	highlightedRows    map[int]bool                            // Data rows to highlight
+	rowColors          map[int]string                          // Data row -> ANSI color code
+	columnColors       map[int]string                          // Column -> ANSI color code for data cells
+	rowCountEnabled    bool                                    // Flag to enable row count
+	rowCountHeader     string                                  // Header of the row number column
+	rowCountStart      int                                     // Number of the first row
+	title              string                                  // Optional title rendered above the table
+	columnSuffixes     map[int]string                          // Suffix decorators appended to data cells
+	dimRowIf           func(row []string) bool                 // Predicate selecting rows to render dimmed
+	cellAlignments     map[[2]int]string                       // (row, col) -> alignment override
+	abbreviations      map[int]map[string]string               // column -> value -> display abbreviation
+	verticalAlignment  string                                  // "top", "middle" or "bottom" for multi-line rows
+	fixedWidths        map[int]int                             // Exact widths for specific columns
+	widthPercents      map[int]float64                         // Column widths as percentages of the console width
+	autoCaption        map[string]string                       // Key/value context rendered below the table
+	footnotes          []string                                // Notes rendered below the table
+	overflow           OverflowMode                            // How overlong cells are handled
+	wrapMode           WrapMode                                // How overlong cells are split into lines
+	hyphenation        bool                                    // Mark words broken across lines with "-"
+	wrapDelimiters     []string                                // List delimiters WrapAuto wraps at
+	headerGroups       []HeaderGroup                           // Spanning labels rendered above the headers
+	collapseDuplicates bool                                    // Collapse runs of identical rows into one
+	autoNumericAlign   bool                                    // Right-align columns that are entirely numeric
+	explicitAlignments map[int]bool                            // Columns whose alignment was set via SetAlignment
+	numericColumns     map[int]bool                            // Entirely numeric columns, detected at render
+	maxRenderLines     int                                     // Maximum rendered lines (0 = unlimited)
+	maxRenderBytes     int                                     // Maximum rendered bytes (0 = unlimited)
+	zebraOddBG         string                                  // Background code for odd data rows (1st, 3rd, ...)
+	zebraEvenBG        string                                  // Background code for even data rows
+	cellFormatter      func(row, col int, value string) string // Styles data cells at render
+	columnFormatters   map[int]func(string) string             // Column -> value formatter applied at render
+	decimalColumns     map[int]bool                            // Columns aligned on the decimal point
+	decimalFracWidths  map[int]int                             // Column -> widest fraction part, set at width calculation
+	decimalUnitWidths  map[int]int                             // Column -> widest trailing "%", set at width calculation
+	barColumns         map[int]float64                         // Column -> value drawn as a full-width bar
+	barLabelWidths     map[int]int                             // Column -> widest bar label, set at width calculation
+	cellPadding        int                                     // Spaces on each side of cell content
+	emptyMessage       string                                  // Placeholder rendered when there are no rows
+	exactWidth         int                                     // Forced total width (0 = content-based)
+	headerRepeat       int                                     // Data rows between repeated headers (0 = no repeat)
+	maxDisplayRows     int                                     // Data rows rendered before the rest are summarized (0 = all)
+	tabWidth           int                                     // Tab stop interval used to expand tabs in cells
+	separatorsBefore   map[int]string                          // Row index -> style of the separator above it
+	headerBars         map[int]float64                         // Column -> ratio shown in the header separator
+	collators          map[int]*collate.Collator               // Column -> collator used by SortByColumn
+	colorLegend        map[string]string                       // ANSI color code -> legend label
+	flexColumns        map[int]bool                            // Columns that absorb extra width in fillWidth mode
+	hashColumns        map[int][]int                           // Hash column index -> source columns
+	showHeader         bool                                    // Render the header row and its separator
+	showDescriptions   bool                                    // Render the description blocks
+	descriptionBullet  string                                  // Marker starting each description line
+	descriptionIndent  int                                     // Spaces before the description bullet
+	descriptionStyles  map[[2]int]descriptionStyle             // (row, description index) -> style
+	treeDepths         map[int]int                             // Data row -> depth in the tree (0 if absent)
+	treeColumn         int                                     // Column the tree is drawn in (-1 if hidden)
+	treePrefixes       []string                                // Data row -> tree connectors, set at render
+	innerBorders       bool                                    // Draw separators between columns and rows
+	rowSeparators      bool                                    // Draw a separator between plain data rows
+	shrinkStrategy     ShrinkStrategy                          // How columns are narrowed to fit the console
+	expandStrategy     ExpandStrategy                          // How extra width is shared out in fillWidth mode
+	protectedColumns   map[int]bool                            // Columns shrunk only when nothing else can be
+	headerSeparator    bool                                    // Draw the separator between headers and data
+	borderChars        BorderChars                             // Glyphs the grid is drawn with
+	visibleCache       map[string]visibleText                  // Cell value -> its stripped form and width
+	// Guards the table: Render and the mutators take it exclusively, since
+	// rendering computes layout state; read-only accessors share it. It also
+	// records whether the layout Render computed last is still valid
+	mu *tableMutex
+
+	// Collapsed and row-numbered copy of the table built by the last render
+	prepared *Table
+	// Exported fields as they were when the cached layout was computed
+	layoutInputs *layoutInputs
+
 	// Reference to the table group this table belongs to (if any)
 
 	group *TableGroup
 }
 
+// SetRowCountOptions sets the header of the row number column added by
+// EnableRowCount (default "#") and the number of the first row (default 1)
+func (t *Table) SetRowCountOptions(header string, start int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rowCountHeader = header
+	t.rowCountStart = start
+}
+
 func (t *Table) EnableRowCount(enabled bool) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	t.rowCountEnabled = enabled
 	return t
 }
 
-// ansiRegexp matches any CSI sequence (e.g. "\x1b[31m", "\x1b[0K", etc.)
-var ansiRegexp = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+// SetTitle sets a title that is rendered centered above the table. A title
+// wider than the table is wrapped at spaces. Centering counts wide characters
+// as two columns; when the space left over can't be split evenly, the extra
+// column goes to the right of the title.
+func (t *Table) SetTitle(title string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.title = title
+}
+
+// SetEmptyMessage sets a placeholder (e.g. "No data") rendered as a single
+// full-width row when the table has no data rows
+func (t *Table) SetEmptyMessage(msg string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.emptyMessage = msg
+}
+
+// SetMaxDisplayRows limits rendering to the first n data rows, followed by a
+// full-width line counting the rows left out (e.g. "… 42 more rows").
+// Descriptions of rows left out are skipped. n <= 0 renders every row.
+func (t *Table) SetMaxDisplayRows(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.maxDisplayRows = n
+}
+
+// SetAutoCaption sets key/value context (e.g. generated time, row count,
+// active filter) rendered as a dim "key=value" line below the table.
+// It composes with SetTitle, which renders above the table.
+func (t *Table) SetAutoCaption(fields map[string]string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.autoCaption = fields
+}
+
+// ErrRenderLimitExceeded is returned by RenderErr when the output would exceed
+// the limits set with SetRenderLimits
+var ErrRenderLimitExceeded = errors.New("table: render limit exceeded")
+
+// SetRenderLimits caps the number of lines and bytes a render may produce, to
+// guard against pathological inputs. Zero or negative values mean no limit.
+func (t *Table) SetRenderLimits(maxLines, maxBytes int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.maxRenderLines = maxLines
+	t.maxRenderBytes = maxBytes
+}
+
+// renderLimiter passes render output on to w, counting it against the
+// configured limits
+type renderLimiter struct {
+	w                  io.StringWriter
+	maxLines, maxBytes int
+	lines, bytes       int // Lines and bytes written so far
+}
+
+// WriteString writes s to the underlying writer, counting its lines and bytes
+func (l *renderLimiter) WriteString(s string) (int, error) {
+	l.lines += strings.Count(s, "\n")
+	l.bytes += len(s)
+	return l.w.WriteString(s)
+}
+
+// check reports whether the output written so far exceeds a limit
+func (l *renderLimiter) check() error {
+	if l.maxLines > 0 && l.lines > l.maxLines {
+		return fmt.Errorf("%w: more than %d lines", ErrRenderLimitExceeded, l.maxLines)
+	}
+	if l.maxBytes > 0 && l.bytes > l.maxBytes {
+		return fmt.Errorf("%w: more than %d bytes", ErrRenderLimitExceeded, l.maxBytes)
+	}
+	return nil
+}
+
+// ansiRegexp matches any CSI sequence (e.g. "\x1b[31m", "\x1b[38:2:255:0:0m",
+// "\x1b[?25l"), OSC sequences terminated by BEL or ST, such as the OSC 8
+// hyperlink wrapper "\x1b]8;;URL\x1b\\label\x1b]8;;\x1b\\", so that only the
+// label remains visible, DCS/SOS/PM/APC strings terminated by ST, and the
+// remaining two-character escapes (e.g. "\x1b7", "\x1b(B")
+var ansiRegexp = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]` +
+	`|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)` +
+	`|\x1b[PX^_][^\x1b]*\x1b\\` +
+	`|\x1b[ -/]*[0-~]`)
+
+// sgrRegexp matches SGR (style) sequences such as "\x1b[31m" or "\x1b[0m"
+var sgrRegexp = regexp.MustCompile(`\x1b\[([0-9;:]*)m`)
+
+// carrySGR makes each of the wrapped lines self-contained: a line starts by
+// re-opening the styles left active by the lines before it, and ends with a
+// reset if any style is still active, so colors neither get lost at a break
+// nor bleed past the cell
+func carrySGR(lines []string) []string {
+	active := ""
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		// Scan the line before re-opening, so carried styles aren't added twice
+		matches := sgrRegexp.FindAllStringSubmatch(line, -1)
+		line = active + line
+		for _, m := range matches {
+			switch params := m[1]; {
+			case params == "" || params == "0":
+				active = ""
+			case strings.HasPrefix(params, "0;"):
+				active = m[0]
+			default:
+				active += m[0]
+			}
+		}
+		if active != "" {
+			line += "\x1b[0m"
+		}
+		out[i] = line
+	}
+	return out
+}
 
 // stripANSI removes ALL ANSI escape sequences from s.
 func stripANSI(s string) string {
+	if strings.IndexByte(s, '\x1b') < 0 {
+		return s
+	}
 	return ansiRegexp.ReplaceAllString(s, "")
 }
 
+// StripANSI returns s without its ANSI escape sequences (colors, styles,
+// cursor controls, hyperlinks), as the table strips cells to measure them
+func StripANSI(s string) string {
+	return stripANSI(s)
+}
+
+// StripANSIBytes removes all ANSI escape sequences from b like StripANSI,
+// without converting to and from a string. b is returned as is if it has
+// none; otherwise the result is a new slice.
+func StripANSIBytes(b []byte) []byte {
+	if bytes.IndexByte(b, '\x1b') < 0 {
+		return b
+	}
+	return ansiRegexp.ReplaceAll(b, nil)
+}
+
 // extractWrappingANSI splits s into leading CSI prefix, trailing CSI suffix,
 func extractWrappingANSI(s string) (prefix, suffix, core string) {
 	// 1) Peel off all leading CSI sequences
@@ -122,6 +428,31 @@ func extractWrappingANSI(s string) (prefix, suffix, core string) {
 	return
 }
 
+// truncateVisible cuts s to a display width of at most n, counting wide
+// characters as two columns. Embedded ANSI sequences are kept but do not
+// count toward n.
+func truncateVisible(s string, n int) string {
+	var sb strings.Builder
+	width := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			if loc := ansiRegexp.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+				sb.WriteString(s[i : i+loc[1]])
+				i += loc[1]
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if width+runeWidth(r) > n {
+			break
+		}
+		sb.WriteRune(r)
+		i += size
+		width += runeWidth(r)
+	}
+	return sb.String()
+}
+
 // TableGroup manages multiple tables with consistent column widths
 type TableGroup struct {
 	tables       []*Table
@@ -140,27 +471,79 @@ func (g *TableGroup) GetTables() []*Table {
 	return g.tables
 }
 
+// SetAlignment sets the alignment of a column in every table of the group
+func (g *TableGroup) SetAlignment(col int, alignment string) {
+	for _, table := range g.tables {
+		table.SetAlignment(col, alignment)
+	}
+}
+
+// SetDimBorder enables/disables dimmed borders in every table of the group
+func (g *TableGroup) SetDimBorder(enabled bool) {
+	for _, table := range g.tables {
+		table.SetDimBorder(enabled)
+	}
+}
+
+// RenderAll syncs the column widths of the group and renders its tables,
+// each with its own title, separated by blank lines
+func (g *TableGroup) RenderAll() string {
+	g.SyncColumnWidths()
+	rendered := make([]string, len(g.tables))
+	for i, table := range g.tables {
+		rendered[i] = table.Render()
+	}
+	return strings.Join(rendered, "\n")
+}
+
 // SetBorderless enables/disables drawing of any box‐drawing characters.
 func (t *Table) SetBorderless(on bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	t.borderless = on
 }
 
+// SetANSISupport overrides terminal detection of ANSI support. Enabling it
+// restores the default dim borders and header highlighting; disabling it
+// turns them off.
+func (t *Table) SetANSISupport(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.supportANSI = enabled
+	t.dimBorder = enabled
+	t.highlightHeaders = enabled
+}
+
 func (t *Table) SetDimBorder(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	t.dimBorder = enabled
 }
 
 // SetHeaderHighlighting enables/disables header highlighting
 func (t *Table) SetHeaderHighlighting(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	t.highlightHeaders = enabled
 }
 
 // SetHighlightedHeaders sets which headers should be highlighted
 func (t *Table) SetHighlightedHeaders(indices []int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	t.highlightedHeaders = indices
 }
 
 // AddHighlightedHeader adds a header to the highlighted list
 func (t *Table) AddHighlightedHeader(index int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	if index >= 0 && index < len(t.Headers) {
 		t.highlightedHeaders = append(t.highlightedHeaders, index)
 	}
@@ -168,6 +551,9 @@ func (t *Table) AddHighlightedHeader(index int) {
 
 // ClearHighlightedHeaders removes all header highlights
 func (t *Table) ClearHighlightedHeaders() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	t.highlightedHeaders = nil
 }
 
@@ -184,26 +570,149 @@ func (t *Table) isHighlightedHeader(index int) bool {
 	return false
 }
 
+// HighlightCell marks a data cell to be rendered bold
+func (t *Table) HighlightCell(row, col int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if row < 0 || col < 0 || col >= len(t.Headers) {
+		return
+	}
+	t.highlightedCells[[2]int{row, col}] = true
+}
+
+// HighlightRow marks every cell of a data row to be rendered bold
+func (t *Table) HighlightRow(row int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if row < 0 {
+		return
+	}
+	t.highlightedRows[row] = true
+}
+
+// ClearHighlightedCells removes all data cell and row highlights
+func (t *Table) ClearHighlightedCells() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.highlightedCells = make(map[[2]int]bool)
+	t.highlightedRows = make(map[int]bool)
+}
+
+// isHighlightedCell checks if a data cell should be rendered bold
+func (t *Table) isHighlightedCell(rowIndex, colIndex int) bool {
+	return t.supportANSI && (t.highlightedRows[rowIndex] || t.highlightedCells[[2]int{rowIndex, colIndex}])
+}
+
+// SetRowColor tints every cell of a data row with an ANSI color code (e.g.
+// "\x1b[31m"). A row color takes precedence over a column color. An empty
+// code removes the color.
+func (t *Table) SetRowColor(row int, ansi string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if row < 0 {
+		return
+	}
+	if ansi == "" {
+		delete(t.rowColors, row)
+		return
+	}
+	t.rowColors[row] = ansi
+}
+
+// SetColumnColor tints every data cell of a column with an ANSI color code.
+// Headers are not tinted. An empty code removes the color.
+func (t *Table) SetColumnColor(col int, ansi string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if col < 0 || col >= len(t.Headers) {
+		return
+	}
+	if ansi == "" {
+		delete(t.columnColors, col)
+		return
+	}
+	t.columnColors[col] = ansi
+}
+
+// colorCell wraps a line of a data cell in its row or column color, if any,
+// restoring the color after any reset inside the text
+func (t *Table) colorCell(txt string, rowIndex, colIndex int) string {
+	code, ok := t.rowColors[rowIndex]
+	if !ok {
+		code, ok = t.columnColors[colIndex]
+	}
+	if !ok || !t.supportANSI || txt == "" {
+		return txt
+	}
+	return code + strings.ReplaceAll(txt, "\x1b[0m", "\x1b[0m"+code) + "\x1b[0m"
+}
+
 // getStyledChar returns a border character with optional dim styling
 func (t *Table) getStyledChar(char string) string {
 	if t.borderless {
 		return " "
 	}
+	char = t.glyph(char)
 	if t.dimBorder && t.supportANSI {
 		return DimStyleStart + char + DimStyleEnd
 	}
 	return char
 }
 
+// SetInnerBorders shows/hides the separators between columns and between
+// rows, leaving only the outer frame. Column widths are unaffected; hidden
+// column separators are drawn as spaces.
+func (t *Table) SetInnerBorders(show bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.innerBorders = show
+}
+
+// getInnerChar returns a border character drawn inside the outer frame,
+// which is blank (or a plain horizontal line for junctions on the top and
+// bottom borders) when inner borders are hidden
+func (t *Table) getInnerChar(char string) string {
+	if t.innerBorders {
+		return t.getStyledChar(char)
+	}
+	switch char {
+	case TopT, BottomT:
+		return t.getStyledChar(HLine)
+	}
+	return " "
+}
+
+// cellDivider returns the vertical border after cell ci of count cells: the
+// outer border after the last cell, an inner one otherwise
+func (t *Table) cellDivider(ci, count int) string {
+	if ci == count-1 {
+		return t.getStyledChar(VLine)
+	}
+	return t.getInnerChar(VLine)
+}
+
 // getStyledHLine returns a horizontal line string with optional dim styling
 func (t *Table) getStyledHLine(width int) string {
+	return t.getStyledRepeat(HLine, width)
+}
+
+// getStyledRepeat returns a border character repeated width times with
+// optional dim styling
+func (t *Table) getStyledRepeat(char string, width int) string {
 	if t.borderless {
 		return strings.Repeat(" ", width)
 	}
+	char = t.glyph(char)
 	if t.dimBorder && t.supportANSI {
-		return DimStyleStart + strings.Repeat(HLine, width) + DimStyleEnd
+		return DimStyleStart + strings.Repeat(char, width) + DimStyleEnd
 	}
-	return strings.Repeat(HLine, width)
+	return strings.Repeat(char, width)
 }
 
 // getHighlightedText returns text with bold styling if it should be highlighted
@@ -220,165 +729,1249 @@ func (t *Table) getHighlightedText(text string, headerIndex int) string {
 
 // Add adds a table to the group
 func (g *TableGroup) Add(table *Table) {
+	table.mu.Lock()
+	defer table.mu.Unlock()
+
 	g.tables = append(g.tables, table)
 	table.group = g
 }
 
 // SetFillWidth sets whether the table should expand to fill the console width
 func (t *Table) SetFillWidth(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	t.fillWidth = enabled
 }
 
-// SetConsoleWidth sets the maximum width for the table
-func (t *Table) SetConsoleWidth(width int) {
-	t.consoleWidth = width
-}
+// SetPadding sets the number of spaces on each side of cell content
+// (default 1). Negative values are ignored.
+func (t *Table) SetPadding(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
-// SetAlignment sets the alignment for a specific column
-func (t *Table) SetAlignment(columnIndex int, alignment string) {
-	if columnIndex >= 0 && columnIndex < len(t.alignments) {
-		t.alignments[columnIndex] = alignment
+	if n >= 0 {
+		t.cellPadding = n
 	}
 }
 
-// SetMaxWidth sets the maximum width for a specific column
-func (t *Table) SetMaxWidth(columnIndex int, maxWidth int) {
-	if columnIndex >= 0 && columnIndex < len(t.Headers) {
-		t.maxWidths[columnIndex] = maxWidth
-	}
+// paddedWidth returns the width of a column including its cell padding
+func (t *Table) paddedWidth(contentWidth int) int {
+	return contentWidth + 2*t.cellPadding
 }
 
-// AddRow adds a new row to the table
-func (t *Table) AddRow(row []string) {
-	for len(row) < len(t.Headers) {
-		row = append(row, "")
+// SetTabWidth sets the tab stop interval used to expand tab characters in
+// headers and cells (default 8). Tab stops count from the start of each
+// line, wrapped lines included. Values below 1 are ignored.
+func (t *Table) SetTabWidth(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if n >= 1 {
+		t.tabWidth = n
 	}
-	t.Rows = append(t.Rows, row)
 }
 
-// AddDescription adds a description for a specific row
-func (t *Table) AddDescription(rowIndex int, description string) {
-	if rowIndex >= 0 && rowIndex < len(t.Rows) {
-		if _, ok := t.Descriptions[rowIndex]; !ok {
-			t.Descriptions[rowIndex] = []string{}
-			t.DescriptionTitles[rowIndex] = []string{}
+// expandTabs replaces tabs in s with spaces up to the next tab stop. Columns
+// are counted from the start of each line, wide characters take two and ANSI
+// sequences take no space.
+func expandTabs(s string, tabWidth int) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	var sb strings.Builder
+	col := 0
+	for i := 0; i < len(s); {
+		if loc := ansiRegexp.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+			sb.WriteString(s[i : i+loc[1]])
+			i += loc[1]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		switch r {
+		case '\t':
+			n := tabWidth - col%tabWidth
+			sb.WriteString(strings.Repeat(" ", n))
+			col += n
+		case '\n':
+			sb.WriteRune(r)
+			col = 0
+		default:
+			sb.WriteRune(r)
+			col += runeWidth(r)
 		}
-		t.Descriptions[rowIndex] = append(t.Descriptions[rowIndex], description)
-		t.DescriptionTitles[rowIndex] = append(t.DescriptionTitles[rowIndex], "")
 	}
+	return sb.String()
 }
 
-// AddDescriptionWithTitle adds a description with a title for a specific row
-func (t *Table) AddDescriptionWithTitle(rowIndex int, title string, description string) {
-	if rowIndex >= 0 && rowIndex < len(t.Rows) {
-		if _, ok := t.Descriptions[rowIndex]; !ok {
-			t.Descriptions[rowIndex] = []string{}
-			t.DescriptionTitles[rowIndex] = []string{}
+// SetFlexColumns restricts fillWidth expansion to the given columns, so only
+// they absorb extra space. With no flex columns, all columns may expand.
+func (t *Table) SetFlexColumns(cols []int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.flexColumns = make(map[int]bool, len(cols))
+	for _, col := range cols {
+		if col >= 0 && col < len(t.Headers) {
+			t.flexColumns[col] = true
 		}
-		t.Descriptions[rowIndex] = append(t.Descriptions[rowIndex], description)
-		t.DescriptionTitles[rowIndex] = append(t.DescriptionTitles[rowIndex], title)
 	}
 }
 
-// formatCellContent formats a cell's content with alignment and padding
-func (t *Table) formatCellContent(content string, colIndex int) string {
-	w := t.columnWidths[colIndex]
+// SetConsoleWidth sets the maximum width for the table
+func (t *Table) SetConsoleWidth(width int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
-	// Strip ANSI codes for length calculation
-	strippedContent := stripANSI(content)
-	contentLength := utf8.RuneCountInString(strippedContent)
+	t.consoleWidth = width
+}
 
-	switch t.alignments[colIndex] {
-	case "right":
-		padding := w - contentLength
-		if padding < 0 {
-			padding = 0
-		}
-		return fmt.Sprintf(" %s%s ", strings.Repeat(" ", padding), content)
-	case "center":
-		totalPad := w - contentLength
-		if totalPad < 0 {
-			totalPad = 0
-		}
-		left := totalPad / 2
-		return fmt.Sprintf(" %s%s%s ",
-			strings.Repeat(" ", left), content,
-			strings.Repeat(" ", totalPad-left))
-	default:
-		padding := w - contentLength
-		if padding < 0 {
-			padding = 0
-		}
-		return fmt.Sprintf(" %s%s ", content, strings.Repeat(" ", padding))
+// SetHeaders replaces the column headers. The number of headers must match
+// the current column count, since changing it would desync rows and alignments.
+func (t *Table) SetHeaders(headers []string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(headers) != len(t.Headers) {
+		return fmt.Errorf("got %d headers for %d columns", len(headers), len(t.Headers))
 	}
+	t.Headers = make([]string, len(headers))
+	copy(t.Headers, headers)
+	// Force the widths to be recalculated at render
+	t.columnWidths = make([]int, len(headers))
+	return nil
 }
 
-// calculateInitialColumnWidths computes the initial width for each column
-func (t *Table) calculateInitialColumnWidths() {
-	// Ensure columnWidths is properly initialized
-	if len(t.columnWidths) != len(t.Headers) {
-		t.columnWidths = make([]int, len(t.Headers))
-	}
+// SetAlignment sets the alignment for a specific column
+func (t *Table) SetAlignment(columnIndex int, alignment string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
-	// Reset all column widths to 0 to start fresh
-	for i := range t.columnWidths {
-		t.columnWidths[i] = 0
+	if columnIndex >= 0 && columnIndex < len(t.alignments) {
+		t.alignments[columnIndex] = alignment
+		t.explicitAlignments[columnIndex] = true
 	}
+}
 
-	// Calculate minimum width needed for headers
-	for i, header := range t.Headers {
-		// headers have no ANSI, but let's strip anyway for consistency
-		vis := stripANSI(header)
-		if l := utf8.RuneCountInString(vis); l > t.columnWidths[i] {
-			t.columnWidths[i] = l
-		}
+// SetAutoNumericAlignment enables/disables right-aligning columns whose
+// non-empty cells are all numeric (see numericRegexp). Columns aligned via
+// SetAlignment are left as configured.
+func (t *Table) SetAutoNumericAlignment(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.autoNumericAlign = enabled
+}
+
+// numericRegexp matches numbers with optional sign, currency symbol,
+// thousands separators, fraction and percent sign (e.g. "-$1,234.50", "42%")
+var numericRegexp = regexp.MustCompile(`^[+-]?[$€£¥]?(\d{1,3}(,\d{3})+|\d+)(\.\d+)?%?$`)
+
+// isNumeric checks if a cell's visible content is a number
+func isNumeric(s string) bool {
+	s = strings.TrimSpace(stripANSI(s))
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
 	}
+	return numericRegexp.MatchString(s)
+}
 
-	// Calculate minimum width needed for each cell
-	for _, row := range t.Rows {
-		for i, cell := range row {
-			if i >= len(t.columnWidths) {
+// detectNumericColumns records which columns contain only numeric cells.
+// Columns with no non-empty cells are not considered numeric.
+func (t *Table) detectNumericColumns() {
+	t.numericColumns = make(map[int]bool)
+	if !t.autoNumericAlign {
+		return
+	}
+	for ci := range t.Headers {
+		numeric, seen := true, false
+		for ri, row := range t.Rows {
+			if ci >= len(row) {
 				continue
 			}
-			// strip out color codes before measuring
-			vis := stripANSI(cell)
-			if l := utf8.RuneCountInString(vis); l > t.columnWidths[i] {
-				t.columnWidths[i] = l
+			cell := t.cellDisplayValue(ri, ci, row[ci])
+			if strings.TrimSpace(stripANSI(cell)) == "" {
+				continue
+			}
+			seen = true
+			if !isNumeric(cell) {
+				numeric = false
+				break
 			}
 		}
+		t.numericColumns[ci] = numeric && seen
 	}
+}
 
-	// Apply max widths if specified
-	for i, width := range t.columnWidths {
-		// Apply global max column width
-		if width > maxColumnWidth {
-			t.columnWidths[i] = maxColumnWidth
-		}
-		// Apply column-specific max width
-		if maxWidth, exists := t.maxWidths[i]; exists && t.columnWidths[i] > maxWidth {
-			t.columnWidths[i] = maxWidth
-		}
+// SetDecimalAlignment aligns the numeric cells of col on their decimal point,
+// with integers aligned as if they had no fraction and a trailing "%" kept
+// out of the fraction. Other cells in the column are right-aligned. Combine
+// with SetColumnFormatter(col, GroupThousands) to group the digits.
+func (t *Table) SetDecimalAlignment(col int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if col < 0 || col >= len(t.Headers) {
+		return
 	}
+	t.decimalColumns[col] = true
 }
 
-// adjustColumnWidthsToFit adjusts column widths to fit the console
-func (t *Table) adjustColumnWidthsToFit() {
-	// Calculate current table width including borders and padding
-	total := 1 // Left border
-	for _, w := range t.columnWidths {
-		total += w + 2 + 1 // Content + padding + separator
+// splitUnit splits a number into its digits and its trailing "%", if any
+func splitUnit(s string) (number, unit string) {
+	s = strings.TrimSpace(s)
+	if number, ok := strings.CutSuffix(s, "%"); ok {
+		return number, "%"
 	}
+	return s, ""
+}
 
-	// If table exceeds terminal width, shrink columns
-	if total > t.consoleWidth {
+// fractionWidth returns the width of the part of a number from its decimal
+// point on, without any trailing "%", or 0 if it has no fraction
+func fractionWidth(s string) int {
+	s, _ = splitUnit(s)
+	if i := strings.LastIndex(s, "."); i >= 0 {
+		return utf8.RuneCountInString(s[i:])
+	}
+	return 0
+}
+
+// detectDecimalWidths records the widest fraction part and unit of each
+// decimal-aligned column, which its numeric cells are padded to
+func (t *Table) detectDecimalWidths() {
+	t.decimalFracWidths = make(map[int]int, len(t.decimalColumns))
+	t.decimalUnitWidths = make(map[int]int, len(t.decimalColumns))
+	for ci := range t.decimalColumns {
+		widest, widestUnit := 0, 0
+		for _, row := range t.Rows {
+			if ci >= len(row) {
+				continue
+			}
+			cell := stripANSI(t.cellValue(ci, row[ci]))
+			if isNumeric(cell) {
+				_, unit := splitUnit(cell)
+				widest = max(widest, fractionWidth(cell))
+				widestUnit = max(widestUnit, len(unit))
+			}
+		}
+		t.decimalFracWidths[ci] = widest
+		t.decimalUnitWidths[ci] = widestUnit
+	}
+}
+
+// alignDecimal pads a numeric cell of a decimal-aligned column after its
+// fraction and after its unit, so that decimal points line up when the
+// column is right-aligned
+func (t *Table) alignDecimal(colIndex int, cell string) string {
+	prefix, suffix, core := extractWrappingANSI(cell)
+	number, unit := splitUnit(core)
+	return prefix + number +
+		strings.Repeat(" ", max(t.decimalFracWidths[colIndex]-fractionWidth(stripANSI(number)), 0)) + unit +
+		strings.Repeat(" ", max(t.decimalUnitWidths[colIndex]-len(unit), 0)) + suffix
+}
+
+// GroupThousands inserts "," thousands separators into the integer part of a
+// number (e.g. "-1234567.5" becomes "-1,234,567.5"), keeping any sign,
+// currency symbol, fraction and "%". Other values, and numbers that are
+// already grouped, are returned unchanged. It can be passed to
+// SetColumnFormatter as is.
+func GroupThousands(s string) string {
+	m := groupableRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return s
+	}
+	digits := m[2]
+	var sb strings.Builder
+	sb.WriteString(m[1])
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(d)
+	}
+	sb.WriteString(m[3])
+	return sb.String()
+}
+
+// groupableRegexp matches an ungrouped number: its sign and currency, its
+// integer digits, and its fraction and "%"
+var groupableRegexp = regexp.MustCompile(`^(\s*[+-]?[$€£¥]?)(\d+)((?:\.\d+)?%?\s*)$`)
+
+// columnAlignment returns the effective alignment for a column
+func (t *Table) columnAlignment(colIndex int) string {
+	if t.decimalColumns[colIndex] {
+		return "right"
+	}
+	if t.numericColumns[colIndex] && !t.explicitAlignments[colIndex] {
+		return "right"
+	}
+	return t.alignments[colIndex]
+}
+
+// SetColumnWidth pins a column to an exact width regardless of its content.
+// Longer content still wraps. Fixed columns are only shrunk to fit the console
+// when no other column can shrink.
+func (t *Table) SetColumnWidth(col, width int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if col >= 0 && col < len(t.Headers) && width > 0 {
+		t.fixedWidths[col] = width
+	}
+}
+
+// SetOverflow sets how cells wider than their column are handled
+func (t *Table) SetOverflow(mode OverflowMode) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.overflow = mode
+}
+
+// AddHeaderGroup adds a label spanning span columns starting at start,
+// rendered in its own row above the headers. Groups may not overlap.
+func (t *Table) AddHeaderGroup(label string, start, span int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if start < 0 || span < 1 || start+span > len(t.Headers) {
+		return fmt.Errorf("header group %q (start %d, span %d) out of range", label, start, span)
+	}
+	for _, g := range t.headerGroups {
+		if start < g.Start+g.Span && g.Start < start+span {
+			return fmt.Errorf("header group %q overlaps group %q", label, g.Label)
+		}
+	}
+	t.headerGroups = append(t.headerGroups, HeaderGroup{Label: label, Start: start, Span: span})
+	return nil
+}
+
+// headerGroupSpans returns the header groups ordered by start column, with
+// ungrouped columns filled in as unlabeled single-column groups
+func (t *Table) headerGroupSpans() []HeaderGroup {
+	byStart := make(map[int]HeaderGroup, len(t.headerGroups))
+	for _, g := range t.headerGroups {
+		byStart[g.Start] = g
+	}
+	var spans []HeaderGroup
+	for col := 0; col < len(t.columnWidths); {
+		g, ok := byStart[col]
+		if !ok {
+			g = HeaderGroup{Start: col, Span: 1}
+		}
+		spans = append(spans, g)
+		col += g.Span
+	}
+	return spans
+}
+
+// spanWidth returns the content width of a header group, including the
+// padding and separators of the inner column boundaries it covers
+func (t *Table) spanWidth(g HeaderGroup) int {
+	width := 0
+	for i := g.Start; i < g.Start+g.Span; i++ {
+		width += t.columnWidths[i]
+	}
+	return width + (t.paddedWidth(0)+1)*(g.Span-1)
+}
+
+// applyHeaderGroupWidths widens columns so each header group label fits
+// within the combined width of its columns, spreading the extra evenly
+func (t *Table) applyHeaderGroupWidths() {
+	for _, g := range t.headerGroups {
+		extra := displayWidth(g.Label) - t.spanWidth(g)
+		if extra <= 0 {
+			continue
+		}
+		for i := 0; i < g.Span; i++ {
+			t.columnWidths[g.Start+i] += extra / g.Span
+			if i < extra%g.Span {
+				t.columnWidths[g.Start+i]++
+			}
+		}
+	}
+}
+
+// SetColumnWidthPercents sizes columns as percentages of the console width
+// available for content (after borders and padding), overriding content-fit.
+// percents[i] applies to column i; the percentages must sum to at most 100
+// and any remainder is left unallocated.
+func (t *Table) SetColumnWidthPercents(percents []float64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(percents) > len(t.Headers) {
+		return fmt.Errorf("got %d column percentages for %d columns", len(percents), len(t.Headers))
+	}
+	sum := 0.0
+	for i, pct := range percents {
+		if err := checkWidthPercent(i, pct); err != nil {
+			return err
+		}
+		sum += pct
+	}
+	if sum > 100 {
+		return fmt.Errorf("column width percentages sum to %v, more than 100", sum)
+	}
+	t.widthPercents = make(map[int]float64, len(percents))
+	for i, pct := range percents {
+		t.widthPercents[i] = pct
+	}
+	return nil
+}
+
+// SetColumnWidthPercent sizes one column as a percentage of the console width
+// available for content (after borders and padding), overriding content-fit;
+// the other columns are sized as usual in what's left. The percentages set
+// across columns must sum to at most 100.
+func (t *Table) SetColumnWidthPercent(col int, pct float64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if col < 0 || col >= len(t.Headers) {
+		return fmt.Errorf("column %d out of range (0-%d)", col, len(t.Headers)-1)
+	}
+	if err := checkWidthPercent(col, pct); err != nil {
+		return err
+	}
+	sum := pct
+	for i, p := range t.widthPercents {
+		if i != col {
+			sum += p
+		}
+	}
+	if sum > 100 {
+		return fmt.Errorf("column width percentages sum to %v, more than 100", sum)
+	}
+	t.widthPercents[col] = pct
+	return nil
+}
+
+// checkWidthPercent rejects a column width percentage that is negative or not
+// a finite number
+func checkWidthPercent(col int, pct float64) error {
+	if math.IsNaN(pct) || math.IsInf(pct, 0) {
+		return fmt.Errorf("column %d: invalid width percentage %v", col, pct)
+	}
+	if pct < 0 {
+		return fmt.Errorf("column %d: negative width percentage %v", col, pct)
+	}
+	return nil
+}
+
+// contentBudget returns the console width left for cell content once
+// borders and padding are accounted for
+func (t *Table) contentBudget() int {
+	budget := t.consoleWidth - 1 // Left border
+	for range t.columnWidths {
+		budget -= t.paddedWidth(0) + 1 // Padding + separator
+	}
+	if budget < 0 {
+		budget = 0
+	}
+	return budget
+}
+
+// percentWidth returns the content width for a column taking pct percent of
+// the content budget, never less than 1
+func (t *Table) percentWidth(pct float64) int {
+	w := int(float64(t.contentBudget()) * pct / 100)
+	if w < 1 {
+		w = 1
+	}
+	return w
+}
+
+// SetWrapMode sets how cells wider than their column are split into lines
+func (t *Table) SetWrapMode(mode WrapMode) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.wrapMode = mode
+}
+
+// SetCellAlignment overrides the column alignment for a single data cell
+func (t *Table) SetCellAlignment(row, col int, alignment string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch alignment {
+	case "left", "right", "center":
+	default:
+		return fmt.Errorf("invalid alignment %q: must be left, right or center", alignment)
+	}
+	if row < 0 || col < 0 || col >= len(t.Headers) {
+		return fmt.Errorf("cell (%d, %d) out of range", row, col)
+	}
+	t.cellAlignments[[2]int{row, col}] = alignment
+	return nil
+}
+
+// SetVerticalAlignment sets how cells shorter than the tallest cell in a row
+// are positioned: "top" (default), "middle" or "bottom"
+func (t *Table) SetVerticalAlignment(align string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.verticalAlignment = align
+}
+
+// verticalOffset returns the number of blank lines rendered above a cell of
+// the given height within a row block of maxHeight lines
+func (t *Table) verticalOffset(height, maxHeight int) int {
+	switch t.verticalAlignment {
+	case "bottom":
+		return maxHeight - height
+	case "middle":
+		return (maxHeight - height) / 2
+	default:
+		return 0
+	}
+}
+
+// SetMaxWidth sets the maximum width for a specific column
+func (t *Table) SetMaxWidth(columnIndex int, maxWidth int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if columnIndex >= 0 && columnIndex < len(t.Headers) {
+		t.maxWidths[columnIndex] = maxWidth
+	}
+}
+
+// SetDimRowIf sets a predicate selecting data rows that are rendered in the
+// dim style, e.g. to de-emphasize resolved items. Has no effect without ANSI support.
+func (t *Table) SetDimRowIf(fn func(row []string) bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.dimRowIf = fn
+}
+
+// SetZebra sets alternating background ANSI codes for data rows. The first
+// row uses oddBG. Shading covers the cell padding so stripes are solid, and is
+// only applied with ANSI support.
+func (t *Table) SetZebra(oddBG, evenBG string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.zebraOddBG = oddBG
+	t.zebraEvenBG = evenBG
+}
+
+// shadeRowCell applies the zebra background for a data row to a formatted
+// cell. Resets inside the cell re-open the background so the stripe stays solid.
+func (t *Table) shadeRowCell(cell string, rowIndex int) string {
+	bg := t.zebraOddBG
+	if rowIndex%2 == 1 {
+		bg = t.zebraEvenBG
+	}
+	if !t.supportANSI || bg == "" {
+		return cell
+	}
+	return bg + strings.ReplaceAll(cell, "\x1b[0m", "\x1b[0m"+bg) + "\x1b[0m"
+}
+
+// isDimmedRow checks if a data row should be rendered dimmed
+func (t *Table) isDimmedRow(row []string) bool {
+	return t.supportANSI && t.dimRowIf != nil && t.dimRowIf(row)
+}
+
+// SetColumnSuffix sets a decorator (e.g. "%") appended to every non-empty
+// data cell in a column. Headers are not decorated.
+func (t *Table) SetColumnSuffix(columnIndex int, suffix string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if columnIndex >= 0 && columnIndex < len(t.Headers) {
+		t.columnSuffixes[columnIndex] = suffix
+	}
+}
+
+// SetColumnAbbreviations sets display substitutions for a column (e.g.
+// "SUCCEEDED" -> "OK"). Stored cell values are left unchanged.
+func (t *Table) SetColumnAbbreviations(col int, m map[string]string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if col >= 0 && col < len(t.Headers) {
+		t.abbreviations[col] = m
+	}
+}
+
+// SetCellFormatter sets a callback that styles data cells at render time,
+// e.g. to color "FAIL" cells red. It receives the cell as displayed (after
+// abbreviations and suffixes) and may return an ANSI-wrapped string. The
+// formatter is skipped without ANSI support.
+func (t *Table) SetCellFormatter(fn func(row, col int, value string) string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cellFormatter = fn
+}
+
+// SetColumnFormatter sets a function that formats every data cell of col at
+// render time, e.g. to add thousands separators or format dates. It receives
+// the raw value and is skipped for values with an abbreviation. Output is
+// measured without ANSI codes. A nil fn removes the formatter.
+func (t *Table) SetColumnFormatter(col int, fn func(string) string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if col < 0 || col >= len(t.Headers) {
+		return
+	}
+	if fn == nil {
+		delete(t.columnFormatters, col)
+		return
+	}
+	t.columnFormatters[col] = fn
+}
+
+// cellValue returns a data cell with its abbreviation or column formatter
+// applied, before any decorators. Tabs are expanded once the cell is wrapped,
+// so that tab stops count from the start of each line.
+func (t *Table) cellValue(colIndex int, cell string) string {
+	if abbr, ok := t.abbreviations[colIndex][cell]; ok {
+		cell = abbr
+	} else if fn, ok := t.columnFormatters[colIndex]; ok {
+		cell = fn(cell)
+		if !t.supportANSI {
+			cell = stripANSI(cell)
+		}
+	}
+	return cell
+}
+
+// cellDisplayValue returns a data cell as it will be displayed, with any
+// decorators applied. Width calculation and rendering both go through it so
+// that decorated content is measured and aligned as a whole.
+func (t *Table) cellDisplayValue(rowIndex, colIndex int, cell string) string {
+	cell = t.cellValue(colIndex, cell)
+	if _, ok := t.decimalFracWidths[colIndex]; ok && isNumeric(cell) {
+		// Pad the fraction part so decimal points line up
+		cell = t.alignDecimal(colIndex, cell)
+	}
+	if suffix, ok := t.columnSuffixes[colIndex]; ok && cell != "" {
+		cell += suffix
+	}
+	if t.cellFormatter != nil && t.supportANSI {
+		cell = t.cellFormatter(rowIndex, colIndex, cell)
+	}
+	if colIndex == t.treeColumn && rowIndex >= 0 && rowIndex < len(t.treePrefixes) {
+		cell = t.treePrefixes[rowIndex] + cell
+	}
+	return cell
+}
+
+// CollapseDuplicateRows enables/disables collapsing runs of identical
+// consecutive rows (compared without ANSI codes) into a single row whose last
+// cell is marked with a "(×N)" count. Rows with descriptions are never collapsed.
+func (t *Table) CollapseDuplicateRows(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.collapseDuplicates = enabled
+}
+
+// collapseRows collapses the runs of identical consecutive rows in place,
+// moving the row-keyed settings of the rows that remain with them. Settings
+// of the rows folded into a run's first row are dropped.
+func (t *Table) collapseRows() {
+	rowKey := func(row []string) string {
+		stripped := make([]string, len(row))
+		for i, cell := range row {
+			stripped[i] = stripANSI(cell)
+		}
+		return strings.Join(stripped, "\x00")
+	}
+	hasDesc := func(ri int) bool {
+		return len(t.rowDescriptions(ri)) > 0
+	}
+
+	// newIndex[ri] is the position of row ri if it starts a run; source[i]
+	// is the original index of the row at position i
+	rows := [][]string{}
+	newIndex := make(map[int]int)
+	var source []int
+	for ri := 0; ri < len(t.Rows); {
+		count := 1
+		if !hasDesc(ri) {
+			key := rowKey(t.Rows[ri])
+			for ri+count < len(t.Rows) && !hasDesc(ri+count) && rowKey(t.Rows[ri+count]) == key &&
+				t.treeDepths[ri+count] == t.treeDepths[ri] {
+				count++
+			}
+		}
+
+		row := append([]string{}, t.Rows[ri]...)
+		if count > 1 && len(row) > 0 {
+			last := len(row) - 1
+			row[last] = strings.TrimSpace(fmt.Sprintf("%s (×%d)", row[last], count))
+		}
+		newIndex[ri] = len(rows)
+		source = append(source, ri)
+		rows = append(rows, row)
+		ri += count
+	}
+	t.Rows = rows
+
+	descs := make(map[int][]string)
+	for ri, d := range t.Descriptions {
+		if ni, ok := newIndex[ri]; ok {
+			descs[ni] = d
+		}
+	}
+	titles := make(map[int][]string)
+	for ri, d := range t.DescriptionTitles {
+		if ni, ok := newIndex[ri]; ok {
+			titles[ni] = d
+		}
+	}
+	t.Descriptions, t.DescriptionTitles = descs, titles
+	styles := make(map[[2]int]descriptionStyle)
+	for key, style := range t.descriptionStyles {
+		if ni, ok := newIndex[key[0]]; ok {
+			styles[[2]int{ni, key[1]}] = style
+		}
+	}
+	t.descriptionStyles = styles
+	depths := make(map[int]int)
+	for ri, d := range t.treeDepths {
+		if ni, ok := newIndex[ri]; ok {
+			depths[ni] = d
+		}
+	}
+	t.treeDepths = depths
+
+	cellAlignments := make(map[[2]int]string)
+	for cell, alignment := range t.cellAlignments {
+		if ni, ok := newIndex[cell[0]]; ok {
+			cellAlignments[[2]int{ni, cell[1]}] = alignment
+		}
+	}
+	t.cellAlignments = cellAlignments
+	highlightedCells := make(map[[2]int]bool)
+	for cell := range t.highlightedCells {
+		if ni, ok := newIndex[cell[0]]; ok {
+			highlightedCells[[2]int{ni, cell[1]}] = true
+		}
+	}
+	t.highlightedCells = highlightedCells
+
+	highlightedRows := make(map[int]bool)
+	for ri := range t.highlightedRows {
+		if ni, ok := newIndex[ri]; ok {
+			highlightedRows[ni] = true
+		}
+	}
+	t.highlightedRows = highlightedRows
+	rowColors := make(map[int]string)
+	for ri, code := range t.rowColors {
+		if ni, ok := newIndex[ri]; ok {
+			rowColors[ni] = code
+		}
+	}
+	t.rowColors = rowColors
+	separators := make(map[int]string)
+	for ri, style := range t.separatorsBefore {
+		if ni, ok := newIndex[ri]; ok {
+			separators[ni] = style
+		}
+	}
+	t.separatorsBefore = separators
+
+	if formatter := t.cellFormatter; formatter != nil {
+		t.cellFormatter = func(row, col int, value string) string {
+			if row >= 0 && row < len(source) {
+				row = source[row]
+			}
+			return formatter(row, col, value)
+		}
+	}
+}
+
+// AddHashColumn appends a column whose cells hold a short hash (the first 8
+// hex digits of SHA-256) of the given source columns' values, useful for
+// spotting duplicate or changed rows. Values are hashed without ANSI codes.
+// Hashes are filled in for existing rows and for rows added later, and are
+// fixed once computed: editing a cell doesn't update its row's hash.
+// ReorderColumns moves the hash column and its sources together.
+func (t *Table) AddHashColumn(header string, cols []int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var sources []int
+	for _, c := range cols {
+		if c >= 0 && c < len(t.Headers) {
+			sources = append(sources, c)
+		}
+	}
+
+	col := len(t.Headers)
+	t.Headers = append(t.Headers, header)
+	t.alignments = append(t.alignments, "left")
+	t.columnWidths = append(t.columnWidths, 0)
+	t.hashColumns[col] = sources
+
+	for ri, row := range t.Rows {
+		for len(row) < len(t.Headers) {
+			row = append(row, "")
+		}
+		row[col] = rowHash(row, sources)
+		t.Rows[ri] = row
+	}
+}
+
+// rowHash returns the short hash of the given columns of row
+func rowHash(row []string, cols []int) string {
+	h := sha256.New()
+	for _, c := range cols {
+		h.Write([]byte(stripANSI(row[c])))
+		h.Write([]byte{0}) // Separate values so "ab"+"c" differs from "a"+"bc"
+	}
+	return hex.EncodeToString(h.Sum(nil))[:8]
+}
+
+// AddRow adds a new row to the table. Short rows are padded with empty cells;
+// cells beyond the number of headers are dropped (see AddRowStrict).
+func (t *Table) AddRow(row []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.addRow(row)
+}
+
+// addRow pads or trims a copy of row to the headers, fills its hash columns
+// and appends it
+func (t *Table) addRow(row []string) {
+	row = t.fitRow(slices.Clone(row))
+	for col, sources := range t.hashColumns {
+		row[col] = rowHash(row, sources)
+	}
+	// Measure the cells now, so renders find them in the cache
+	for _, cell := range row {
+		t.visible(cell)
+	}
+	t.Rows = append(t.Rows, row)
+}
+
+// AddRowStrict adds a row like AddRow, but returns an error instead of
+// dropping cells if the row has more cells than there are headers
+func (t *Table) AddRowStrict(row []string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(row) > len(t.Headers) {
+		return fmt.Errorf("row has %d cells, table has %d columns", len(row), len(t.Headers))
+	}
+	t.addRow(row)
+	return nil
+}
+
+// fitRow pads a row with empty cells or drops its extra cells so that it has
+// exactly one cell per header
+func (t *Table) fitRow(row []string) []string {
+	if len(row) > len(t.Headers) {
+		return row[:len(t.Headers):len(t.Headers)]
+	}
+	for len(row) < len(t.Headers) {
+		row = append(row, "")
+	}
+	return row
+}
+
+// AddRows adds several rows to the table, padding short rows like AddRow,
+// and returns the table for chaining
+func (t *Table) AddRows(rows [][]string) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, row := range rows {
+		t.addRow(row)
+	}
+	return t
+}
+
+// Reset removes all rows and descriptions so the table can be refilled,
+// keeping its headers and configuration. Settings tied to row indices (cell
+// alignments, highlights, row colors and separators) are cleared as well.
+func (t *Table) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.Rows = [][]string{}
+	t.Descriptions = make(map[int][]string)
+	t.DescriptionTitles = make(map[int][]string)
+	t.descriptionStyles = make(map[[2]int]descriptionStyle)
+	t.treeDepths = make(map[int]int)
+	for i := range t.columnWidths {
+		t.columnWidths[i] = 0
+	}
+	t.cellAlignments = make(map[[2]int]string)
+	t.highlightedCells = make(map[[2]int]bool)
+	t.highlightedRows = make(map[int]bool)
+	t.rowColors = make(map[int]string)
+	t.separatorsBefore = make(map[int]string)
+	clear(t.visibleCache)
+}
+
+// AddDescription adds a description for a specific row
+func (t *Table) AddDescription(rowIndex int, description string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if rowIndex >= 0 && rowIndex < len(t.Rows) {
+		if _, ok := t.Descriptions[rowIndex]; !ok {
+			t.Descriptions[rowIndex] = []string{}
+			t.DescriptionTitles[rowIndex] = []string{}
+		}
+		t.Descriptions[rowIndex] = append(t.Descriptions[rowIndex], description)
+		t.DescriptionTitles[rowIndex] = append(t.DescriptionTitles[rowIndex], "")
+	}
+}
+
+// AddDescriptionWithTitle adds a description with a title for a specific row
+func (t *Table) AddDescriptionWithTitle(rowIndex int, title string, description string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if rowIndex >= 0 && rowIndex < len(t.Rows) {
+		if _, ok := t.Descriptions[rowIndex]; !ok {
+			t.Descriptions[rowIndex] = []string{}
+			t.DescriptionTitles[rowIndex] = []string{}
+		}
+		t.Descriptions[rowIndex] = append(t.Descriptions[rowIndex], description)
+		t.DescriptionTitles[rowIndex] = append(t.DescriptionTitles[rowIndex], title)
+	}
+}
+
+// SetShowDescriptions shows/hides the description blocks. Hidden
+// descriptions stay stored, and rows are separated by plain borders instead.
+func (t *Table) SetShowDescriptions(show bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.showDescriptions = show
+}
+
+// SetDescriptionBullet sets the bullet (e.g. "• ") starting each line of a
+// description, after indent spaces; wrapped lines are aligned under the text
+func (t *Table) SetDescriptionBullet(bullet string, indent int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if indent < 0 {
+		indent = 0
+	}
+	t.descriptionBullet = bullet
+	t.descriptionIndent = indent
+}
+
+// rowDescriptions returns the descriptions rendered below data row ri
+func (t *Table) rowDescriptions(ri int) []string {
+	if !t.showDescriptions {
+		return nil
+	}
+	return t.Descriptions[ri]
+}
+
+// descriptionStyle is the color and alignment of one description
+type descriptionStyle struct {
+	color     string // ANSI color code wrapping each line
+	alignment string // "left", "right" or "center" within the block
+}
+
+// AddDescriptionStyled adds a description with a title (which may be empty)
+// for a specific row, whose lines are colored with an ANSI color code and
+// aligned "left", "right" or "center" within the description block. An empty
+// color leaves the lines uncolored; an unknown alignment aligns them left.
+func (t *Table) AddDescriptionStyled(rowIndex int, title, description, ansiColor, alignment string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if rowIndex < 0 || rowIndex >= len(t.Rows) {
+		return
+	}
+	t.Descriptions[rowIndex] = append(t.Descriptions[rowIndex], description)
+	t.DescriptionTitles[rowIndex] = append(t.DescriptionTitles[rowIndex], title)
+	if alignment != "right" && alignment != "center" {
+		alignment = "left"
+	}
+	if ansiColor != "" || alignment != "left" {
+		di := len(t.Descriptions[rowIndex]) - 1
+		t.descriptionStyles[[2]int{rowIndex, di}] = descriptionStyle{color: ansiColor, alignment: alignment}
+	}
+}
+
+// formatCellContent formats a cell's content with alignment and padding.
+// rowIndex identifies the data row for per-cell overrides; use -1 for headers.
+func (t *Table) formatCellContent(content string, rowIndex, colIndex int) string {
+	w := t.columnWidths[colIndex]
+
+	// Measure the display width, without ANSI codes; the same lines are
+	// formatted on every render, so they're measured through the cache
+	contentLength := t.visible(content).width
+
+	alignment, ok := t.cellAlignments[[2]int{rowIndex, colIndex}]
+	if !ok {
+		alignment = t.columnAlignment(colIndex)
+	}
+
+	pad := strings.Repeat(" ", t.cellPadding)
+
+	switch alignment {
+	case "right":
+		padding := w - contentLength
+		if padding < 0 {
+			padding = 0
+		}
+		return fmt.Sprintf("%s%s%s%s", pad, strings.Repeat(" ", padding), content, pad)
+	case "center":
+		totalPad := w - contentLength
+		if totalPad < 0 {
+			totalPad = 0
+		}
+		left := totalPad / 2
+		return fmt.Sprintf("%s%s%s%s%s", pad,
+			strings.Repeat(" ", left), content,
+			strings.Repeat(" ", totalPad-left), pad)
+	default:
+		padding := w - contentLength
+		if padding < 0 {
+			padding = 0
+		}
+		return fmt.Sprintf("%s%s%s%s", pad, content, strings.Repeat(" ", padding), pad)
+	}
+}
+
+// longestLineWidth returns the display width of the longest line in s, since
+// embedded newlines are rendered as hard line breaks
+func longestLineWidth(s string) int {
+	longest := 0
+	for _, line := range strings.Split(s, "\n") {
+		if l := displayWidth(line); l > longest {
+			longest = l
+		}
+	}
+	return longest
+}
+
+// calculateInitialColumnWidths computes the initial width for each column
+func (t *Table) calculateInitialColumnWidths() {
+	t.detectDecimalWidths()
+	t.detectBarLabelWidths()
+
+	// Ensure columnWidths is properly initialized
+	if len(t.columnWidths) != len(t.Headers) {
+		t.columnWidths = make([]int, len(t.Headers))
+	}
+
+	// Reset all column widths to 0 to start fresh
+	for i := range t.columnWidths {
+		t.columnWidths[i] = 0
+	}
+
+	// Calculate minimum width needed for headers
+	for i, header := range t.Headers {
+		// headers have no ANSI, but let's strip anyway for consistency
+		if l := t.visible(expandTabs(header, t.tabWidth)).width; l > t.columnWidths[i] {
+			t.columnWidths[i] = l
+		}
+	}
+
+	// Calculate minimum width needed for each cell
+	for ri, row := range t.Rows {
+		for i, cell := range row {
+			if i >= len(t.columnWidths) {
+				continue
+			}
+			// strip out color codes before measuring
+			vis := expandTabs(t.cellDisplayValue(ri, i, cell), t.tabWidth)
+			if l := t.visible(vis).width; l > t.columnWidths[i] {
+				t.columnWidths[i] = l
+			}
+		}
+	}
+
+	// Leave room for the bars next to their labels
+	for i, labelWidth := range t.barLabelWidths {
+		if min := labelWidth + 1 + minBarLength; i < len(t.columnWidths) && t.columnWidths[i] < min {
+			t.columnWidths[i] = min
+		}
+	}
+
+	// Apply max widths if specified
+	for i, width := range t.columnWidths {
+		// Apply global max column width
+		if t.defaultMaxWidth > 0 && width > t.defaultMaxWidth {
+			t.columnWidths[i] = t.defaultMaxWidth
+		}
+		// Apply column-specific max width
+		if maxWidth, exists := t.maxWidths[i]; exists && t.columnWidths[i] > maxWidth {
+			t.columnWidths[i] = maxWidth
+		}
+		// Fixed and percentage widths override content-based sizing entirely
+		if fixed, exists := t.fixedWidths[i]; exists {
+			t.columnWidths[i] = fixed
+		}
+		if pct, exists := t.widthPercents[i]; exists {
+			t.columnWidths[i] = t.percentWidth(pct)
+		}
+	}
+
+	// Header group labels are a lower bound on their columns' combined width
+	t.applyHeaderGroupWidths()
+}
+
+// isPinned checks if a column's width is set explicitly, either fixed via
+// SetColumnWidth or as a percentage of the console width
+func (t *Table) isPinned(col int) bool {
+	_, fixed := t.fixedWidths[col]
+	_, pct := t.widthPercents[col]
+	return fixed || pct
+}
+
+// SetColumnPriority protects a column from shrinking when the table is wider
+// than the console: protected columns are only narrowed once no other column
+// can be.
+func (t *Table) SetColumnPriority(col int, protected bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if col < 0 || col >= len(t.Headers) {
+		return
+	}
+	if protected {
+		t.protectedColumns[col] = true
+	} else {
+		delete(t.protectedColumns, col)
+	}
+}
+
+// shrinkTier returns the order in which a column gives up width: 0 for
+// ordinary columns, 1 for pinned columns and 2 for protected columns
+func (t *Table) shrinkTier(col int) int {
+	switch {
+	case t.protectedColumns[col]:
+		return 2
+	case t.isPinned(col):
+		return 1
+	}
+	return 0
+}
+
+// SetColumnMinWidth sets the width a column is never shrunk below to fit the
+// console (3 by default). A table whose columns are all at their minimums
+// stays wider than the console and overflows horizontally.
+func (t *Table) SetColumnMinWidth(col, min int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if col < 0 || col >= len(t.Headers) || min < 1 {
+		return
+	}
+	t.minWidths[col] = min
+}
+
+// minColumnWidth returns the width a column is never shrunk below
+func (t *Table) minColumnWidth(col int) int {
+	if min, ok := t.minWidths[col]; ok {
+		return min
+	}
+	return 3
+}
+
+// widestShrinkableColumn returns the index of the widest column that can still
+// be shrunk, or -1 if none can. Pinned columns are only considered once no
+// other column can shrink, and protected columns after those.
+func (t *Table) widestShrinkableColumn() int {
+	for tier := 0; tier <= 2; tier++ {
+		maxW, idx := 0, -1
+		for i, w := range t.columnWidths {
+			if t.shrinkTier(i) > tier {
+				continue
+			}
+			// Don't shrink below the column's minimum usable width
+			if w > maxW && w > t.minColumnWidth(i) {
+				maxW, idx = w, i
+			}
+		}
+		if idx >= 0 {
+			return idx
+		}
+	}
+	return -1
+}
+
+// SetShrinkStrategy sets how columns are narrowed when the table is wider
+// than the console
+func (t *Table) SetShrinkStrategy(s ShrinkStrategy) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.shrinkStrategy = s
+}
+
+// shrinkProportionally narrows the ordinary columns by excess in total, each
+// in proportion to its width above its minimum, and returns the part of
+// excess that could not be removed
+func (t *Table) shrinkProportionally(excess int) int {
+	slack := 0
+	for i, w := range t.columnWidths {
+		if min := t.minColumnWidth(i); t.shrinkTier(i) == 0 && w > min {
+			slack += w - min
+		}
+	}
+	if slack == 0 {
+		return excess
+	}
+	if excess >= slack {
+		for i, w := range t.columnWidths {
+			if min := t.minColumnWidth(i); t.shrinkTier(i) == 0 && w > min {
+				t.columnWidths[i] = min
+			}
+		}
+		return excess - slack
+	}
+
+	removed := 0
+	for i, w := range t.columnWidths {
+		if min := t.minColumnWidth(i); t.shrinkTier(i) == 0 && w > min {
+			cut := excess * (w - min) / slack
+			t.columnWidths[i] -= cut
+			removed += cut
+		}
+	}
+	// Rounding leftovers come off the widest columns
+	for removed < excess {
+		idx := t.widestShrinkableColumn()
+		if idx < 0 {
+			break
+		}
+		t.columnWidths[idx]--
+		removed++
+	}
+	return excess - removed
+}
+
+// adjustColumnWidthsToFit adjusts column widths to fit the console
+func (t *Table) adjustColumnWidthsToFit() {
+	// Calculate current table width including borders and padding
+	total := 1 // Left border
+	for _, w := range t.columnWidths {
+		total += t.paddedWidth(w) + 1 // Content + padding + separator
+	}
+
+	// If table exceeds terminal width, shrink columns
+	if total > t.consoleWidth {
 		excess := total - t.consoleWidth
+		if t.shrinkStrategy == ShrinkProportional {
+			excess = t.shrinkProportionally(excess)
+		}
 		for excess > 0 {
-			maxW, idx := 0, -1
-			for i, w := range t.columnWidths {
-				if w > maxW && w > 3 {
-					maxW, idx = w, i
-				}
-			}
+			idx := t.widestShrinkableColumn()
 			if idx < 0 {
 				break
 			}
@@ -389,79 +1982,187 @@ func (t *Table) adjustColumnWidthsToFit() {
 
 }
 
-// smartSplitCellContent splits a cell, preserving any ANSI prefix/suffix,
-// and applies your original: comma-first, slash-second, then word-fallback.
+// smartSplitCellContent wraps a cell into lines that each carry the styles
+// active at their start and reset any still open at their end
 func (t *Table) smartSplitCellContent(content string, colIndex int) []string {
+	return carrySGR(t.splitCellContent(content, colIndex))
+}
+
+// splitCellContent splits a cell, preserving any ANSI prefix/suffix,
+// and applies your original: comma-first, slash-second, then word-fallback.
+func (t *Table) splitCellContent(content string, colIndex int) []string {
 	// 1) Peel off any ANSI wrapper
 	prefix, suffix, core := extractWrappingANSI(content)
 
-	// 2) Measure the visible length
+	// Codes inside the core mean the wrapper isn't uniform across the cell:
+	// it then only opens the first line and closes the last, and the styles
+	// in between are carried over by smartSplitCellContent
+	inner := ansiRegexp.MatchString(core)
+
+	// Embedded newlines are hard breaks: wrap each segment on its own, keeping
+	// the wrapper on every segment so styles don't bleed across lines
+	if strings.Contains(core, "\n") {
+		var out []string
+		segs := strings.Split(core, "\n")
+		for i, seg := range segs {
+			seg = strings.TrimSuffix(seg, "\r")
+			if !inner || i == 0 {
+				seg = prefix + seg
+			}
+			if !inner || i == len(segs)-1 {
+				seg += suffix
+			}
+			out = append(out, t.splitCellContent(seg, colIndex)...)
+		}
+		return out
+	}
+
+	// 2) Measure the visible length, with tabs expanded from the line start
 	maxW := t.columnWidths[colIndex]
-	visible := stripANSI(core)
-	if utf8.RuneCountInString(visible) <= maxW {
+	expanded := expandTabs(core, t.tabWidth)
+	if t.visible(expanded).width <= maxW {
 		// nothing to wrap
-		return []string{prefix + core + suffix}
+		return []string{prefix + expanded + suffix}
 	}
 
-	// 3) Try your original split strategies on the **plain** core,
+	// In truncate/ellipsis mode the cell is clipped to a single line
+	if t.overflow == OverflowTruncate {
+		return []string{prefix + truncateVisible(expanded, maxW) + suffix}
+	}
+	if t.overflow == OverflowEllipsis {
+		if maxW < 1 {
+			return []string{prefix + suffix}
+		}
+		return []string{prefix + truncateVisible(expanded, maxW-1) + "…" + suffix}
+	}
+
+	// 3) Split the **plain** core according to the wrap mode,
 	//    then re-attach prefix/suffix to each piece.
 
 	var parts []string
-	if strings.Contains(core, ",") {
-		parts = t.splitCommaSeparatedList(core, maxW)
-	} else if strings.Contains(core, "/") || strings.Contains(core, ".") {
-		parts = t.splitLongString(core, maxW)
-	} else {
+	switch t.wrapMode {
+	case WrapNone:
+		return []string{prefix + expanded + suffix}
+	case WrapWord:
 		parts = t.splitByWords(core, maxW)
+	case WrapChar:
+		parts = splitByWidth(core, maxW)
+	default:
+		if delim := t.listDelimiter(core); delim != "" {
+			parts = t.splitDelimitedList(core, delim, maxW)
+		} else if strings.Contains(core, "/") || strings.Contains(core, ".") {
+			parts = t.splitLongString(core, maxW)
+		} else {
+			parts = t.splitByWords(core, maxW)
+		}
 	}
 
+	// Tab stops count from the start of each wrapped line; a line the
+	// expanded tabs push past the width is cut at the width
+	var lines []string
+	for _, part := range parts {
+		if !strings.Contains(part, "\t") {
+			lines = append(lines, part)
+			continue
+		}
+		part = expandTabs(part, t.tabWidth)
+		if displayWidth(part) > maxW {
+			lines = append(lines, splitByWidth(part, maxW)...)
+		} else {
+			lines = append(lines, part)
+		}
+	}
+	parts = lines
+
 	// 4) Re-attach ANSI to every wrapped line
 	out := make([]string, len(parts))
 	for i, line := range parts {
-		out[i] = prefix + line + suffix
+		if !inner || i == 0 {
+			line = prefix + line
+		}
+		if !inner || i == len(parts)-1 {
+			line += suffix
+		}
+		out[i] = line
 	}
 	return out
 }
 
+// splitLongString breaks paths and dotted identifiers before each "/" or ".",
+// keeping the delimiter on the following piece
 func (t *Table) splitLongString(content string, maxWidth int) []string {
-	parts := strings.Split(content, "/")
+	var parts []string
+	start := 0
+	for i, r := range content {
+		if (r == '/' || r == '.') && i > start {
+			parts = append(parts, content[start:i])
+			start = i
+		}
+	}
+	parts = append(parts, content[start:])
+
 	var res []string
 	line := ""
-	for i, part := range parts {
-		if i > 0 {
-			part = "/" + part
-		}
-		if line != "" && utf8.RuneCountInString(line+part) > maxWidth {
+	for _, part := range parts {
+		if line != "" && displayWidth(line+part) > maxWidth {
 			res = append(res, line)
 			line = part
 		} else {
 			line += part
 		}
-		if utf8.RuneCountInString(line) > maxWidth {
+		if displayWidth(line) > maxWidth {
 			chunks := t.splitByWords(line, maxWidth)
 			res = append(res, chunks...)
 			line = ""
 		}
 	}
-	if line != "" {
-		res = append(res, line)
-	}
-	return res
+	if line != "" {
+		res = append(res, line)
+	}
+	return res
+}
+
+// SetWrapDelimiters sets the list delimiters that WrapAuto wraps at, tried in
+// order (default [","]). Paths and dotted names are still split at "/" and "."
+// when no delimiter is present.
+func (t *Table) SetWrapDelimiters(delims []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.wrapDelimiters = nil
+	for _, d := range delims {
+		if d != "" {
+			t.wrapDelimiters = append(t.wrapDelimiters, d)
+		}
+	}
+}
+
+// listDelimiter returns the first wrap delimiter present in content, or ""
+func (t *Table) listDelimiter(content string) string {
+	for _, d := range t.wrapDelimiters {
+		if strings.Contains(content, d) {
+			return d
+		}
+	}
+	return ""
 }
 
-// splitCommaSeparatedList breaks at commas, keeping items under maxWidth
-func (t *Table) splitCommaSeparatedList(content string, maxWidth int) []string {
-	parts := strings.Split(content, ",")
+// splitDelimitedList breaks a delim-separated list (e.g. comma-separated) at
+// the delimiters, keeping items under maxWidth. Items on the same line are
+// joined by the delimiter and a space; the delimiter is dropped at a break.
+func (t *Table) splitDelimitedList(content, delim string, maxWidth int) []string {
+	parts := strings.Split(content, delim)
+	sep := delim + " "
 	var res []string
 	line := ""
 	for i, part := range parts {
 		part = strings.TrimSpace(part)
 		if i > 0 {
-			part = ", " + part
+			part = sep + part
 		}
-		if line != "" && utf8.RuneCountInString(line+part) > maxWidth {
+		if line != "" && displayWidth(line+part) > maxWidth {
 			res = append(res, line)
-			line = strings.TrimPrefix(part, ", ")
+			line = strings.TrimPrefix(part, sep)
 		} else {
 			line += part
 		}
@@ -472,31 +2173,116 @@ func (t *Table) splitCommaSeparatedList(content string, maxWidth int) []string {
 	return res
 }
 
-// splitByWords splits on spaces to keep each line under maxWidth
+// SetDefaultMaxColumnWidth sets the width cap applied to every column
+// (default 50). A value of 0 or less removes the cap; per-column limits from
+// SetMaxWidth still apply.
+func (t *Table) SetDefaultMaxColumnWidth(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.defaultMaxWidth = n
+}
+
+// SetShowHeader shows/hides the header row. A hidden header still counts
+// toward column widths; the top border then leads directly into the first row.
+func (t *Table) SetShowHeader(show bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.showHeader = show
+}
+
+// SetHyphenation enables/disables marking words that are broken across lines
+// with a trailing "-" on every fragment but the last
+func (t *Table) SetHyphenation(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.hyphenation = enabled
+}
+
+// chunkWord breaks a word that is too wide for maxWidth into fragments, by
+// display width. With hyphenation, every fragment but the last ends in "-"
+// and is one column narrower. A character wider than a fragment gets one to
+// itself.
+func (t *Table) chunkWord(word string, maxWidth int) []string {
+	if maxWidth < 1 {
+		return []string{word}
+	}
+	size, mark := maxWidth, ""
+	if t.hyphenation && maxWidth > 1 {
+		size, mark = maxWidth-1, "-"
+	}
+
+	var chunks []string
+	runes := []rune(word)
+	for displayWidth(string(runes)) > maxWidth {
+		n, width := 0, 0
+		for n < len(runes) && width+runeWidth(runes[n]) <= size {
+			width += runeWidth(runes[n])
+			n++
+		}
+		n = max(n, 1)
+		chunks = append(chunks, string(runes[:n])+mark)
+		runes = runes[n:]
+	}
+	return append(chunks, string(runes))
+}
+
+// splitByWidth hard-wraps content every maxWidth display columns, counting
+// wide characters as two. ANSI sequences take no space and are never split;
+// a character wider than maxWidth gets a line of its own.
+func splitByWidth(content string, maxWidth int) []string {
+	if maxWidth < 1 {
+		return []string{content}
+	}
+	var res []string
+	var sb strings.Builder
+	width := 0
+	for i := 0; i < len(content); {
+		if content[i] == '\x1b' {
+			if loc := ansiRegexp.FindStringIndex(content[i:]); loc != nil && loc[0] == 0 {
+				sb.WriteString(content[i : i+loc[1]])
+				i += loc[1]
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(content[i:])
+		if w := runeWidth(r); width+w > maxWidth && width > 0 {
+			res = append(res, sb.String())
+			sb.Reset()
+			width = 0
+		}
+		sb.WriteRune(r)
+		i += size
+		width += runeWidth(r)
+	}
+	return append(res, sb.String())
+}
+
+// splitByWords splits on spaces to keep each line under maxWidth. Each word
+// is measured once and the line's width kept as it grows, rather than
+// stripping and measuring the line again for every word.
 func (t *Table) splitByWords(content string, maxWidth int) []string {
 	words := strings.Fields(content)
 	var res []string
-	line := ""
+	line, lineWidth := "", 0
 	for _, w := range words {
-		test := w
-		if line != "" {
-			test = line + " " + w
-		}
-		if utf8.RuneCountInString(test) <= maxWidth {
-			line = test
+		wordWidth := displayWidth(w)
+		if line == "" {
+			line, lineWidth = w, wordWidth
+		} else if lineWidth+1+wordWidth <= maxWidth {
+			line, lineWidth = line+" "+w, lineWidth+1+wordWidth
 		} else {
-			if line != "" {
-				res = append(res, line)
-			}
-			line = w
+			res = append(res, line)
+			line, lineWidth = w, wordWidth
 		}
-		if utf8.RuneCountInString(line) > maxWidth {
+		if lineWidth > maxWidth {
 			// Handle case where single word is too long
-			for utf8.RuneCountInString(line) > maxWidth {
-				part := line[:maxWidth]
-				res = append(res, part)
-				line = line[maxWidth:]
-			}
+			chunks := t.chunkWord(line, maxWidth)
+			res = append(res, chunks[:len(chunks)-1]...)
+			line = chunks[len(chunks)-1]
+			lineWidth = displayWidth(line)
 		}
 	}
 	if line != "" {
@@ -505,16 +2291,221 @@ func (t *Table) splitByWords(content string, maxWidth int) []string {
 	return res
 }
 
+// totalWidth returns the rendered width of the table including borders
+func (t *Table) totalWidth() int {
+	total := 1 // Left border
+	for _, w := range t.columnWidths {
+		total += t.paddedWidth(w) + 1 // Content + padding + separator
+	}
+	return total
+}
+
+// renderTitle renders the table title centered over the table. Titles wider
+// than the table are wrapped rather than widening the table. Centering uses
+// display width so wide characters are accounted for; when the leftover space
+// is odd, the extra column goes to the right of the title.
+func (t *Table) renderTitle() string {
+	if t.title == "" {
+		return ""
+	}
+	width := t.totalWidth()
+	var sb strings.Builder
+	for _, line := range t.smartSplitByWords(t.title, width) {
+		pad := (width - displayWidth(line)) / 2
+		if pad < 0 {
+			pad = 0
+		}
+		if t.supportANSI {
+			line = BoldStyleStart + line + BoldStyleEnd
+		}
+		sb.WriteString(strings.Repeat(" ", pad) + line + "\n")
+	}
+	return sb.String()
+}
+
+// renderAutoCaption renders the auto-caption fields sorted by key, wrapped to
+// the table width
+func (t *Table) renderAutoCaption() string {
+	if len(t.autoCaption) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(t.autoCaption))
+	for k := range t.autoCaption {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + t.autoCaption[k]
+	}
+
+	var sb strings.Builder
+	for _, line := range t.smartSplitByWords(strings.Join(pairs, " "), t.totalWidth()) {
+		if t.supportANSI {
+			line = DimStyleStart + line + DimStyleEnd
+		} else {
+			line = stripANSI(line)
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+// SetColorLegend sets labels for ANSI color codes used in cells (e.g.
+// "\x1b[31m" -> "failed"). A legend listing a swatch and label for each
+// color actually present in the data is rendered below the table.
+func (t *Table) SetColorLegend(m map[string]string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.colorLegend = m
+}
+
+// renderColorLegend renders one swatch line per legend color used in a
+// data cell, sorted by label. Nothing is rendered without ANSI support.
+func (t *Table) renderColorLegend() string {
+	if !t.supportANSI || len(t.colorLegend) == 0 {
+		return ""
+	}
+
+	used := make(map[string]bool)
+	for ri, row := range t.Rows {
+		for ci, cell := range row {
+			cell = t.cellDisplayValue(ri, ci, cell)
+			for code := range t.colorLegend {
+				if strings.Contains(cell, code) {
+					used[code] = true
+				}
+			}
+		}
+	}
+
+	codes := make([]string, 0, len(used))
+	for code := range used {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return t.colorLegend[codes[i]] < t.colorLegend[codes[j]]
+	})
+
+	var sb strings.Builder
+	for _, code := range codes {
+		sb.WriteString(code + "■" + "\x1b[0m " + t.colorLegend[code] + "\n")
+	}
+	return sb.String()
+}
+
+// renderFooter renders everything below the bottom border
+func (t *Table) renderFooter() string {
+	return t.renderFootnotes() + t.renderAutoCaption() + t.renderColorLegend()
+}
+
+// AddFootnote adds a note rendered below the table, outside its borders, on
+// its own lines wrapped to the table width. Footnotes are dimmed along with
+// the borders.
+func (t *Table) AddFootnote(text string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.footnotes = append(t.footnotes, text)
+}
+
+// renderFootnotes renders the footnotes in the order they were added
+func (t *Table) renderFootnotes() string {
+	var sb strings.Builder
+	for _, note := range t.footnotes {
+		if !t.supportANSI {
+			note = stripANSI(note)
+		}
+		for _, line := range t.smartSplitByWords(note, t.totalWidth()) {
+			if t.dimBorder && t.supportANSI {
+				line = DimStyleStart + line + DimStyleEnd
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	return sb.String()
+}
+
 func (t *Table) renderTopBorder() string {
 	var sb strings.Builder
 	sb.WriteString(t.getStyledChar(TopLeft))
 	for i, w := range t.columnWidths {
-		sb.WriteString(t.getStyledHLine(w + 2))
+		sb.WriteString(t.getStyledHLine(t.paddedWidth(w)))
 		if i < len(t.columnWidths)-1 {
-			sb.WriteString(t.getStyledChar(TopT))
+			sb.WriteString(t.getInnerChar(TopT))
+		}
+	}
+	sb.WriteString(t.getStyledChar(TopRight) + "\n")
+	return sb.String()
+}
+
+// renderHeaderGroups renders the top border, the header group label row and
+// the separator between the group row and the headers
+func (t *Table) renderHeaderGroups() string {
+	spans := t.headerGroupSpans()
+	var sb strings.Builder
+
+	// Top border, with junctions only at group boundaries
+	sb.WriteString(t.getStyledChar(TopLeft))
+	for i, g := range spans {
+		sb.WriteString(t.getStyledHLine(t.paddedWidth(t.spanWidth(g))))
+		if i < len(spans)-1 {
+			sb.WriteString(t.getInnerChar(TopT))
 		}
 	}
 	sb.WriteString(t.getStyledChar(TopRight) + "\n")
+
+	// Group labels, centered and wrapped within their spans
+	labelLines := make([][]string, len(spans))
+	maxH := 0
+	for i, g := range spans {
+		labelLines[i] = t.smartSplitByWords(g.Label, t.spanWidth(g))
+		if len(labelLines[i]) > maxH {
+			maxH = len(labelLines[i])
+		}
+	}
+	for line := 0; line < maxH; line++ {
+		sb.WriteString(t.getStyledChar(VLine))
+		for i, g := range spans {
+			txt := ""
+			if line < len(labelLines[i]) {
+				txt = labelLines[i][line]
+			}
+			totalPad := t.spanWidth(g) - displayWidth(txt)
+			if totalPad < 0 {
+				totalPad = 0
+			}
+			if t.supportANSI && t.highlightHeaders && txt != "" {
+				txt = BoldStyleStart + txt + BoldStyleEnd
+			}
+			left := totalPad / 2
+			pad := strings.Repeat(" ", t.cellPadding)
+			sb.WriteString(pad + strings.Repeat(" ", left) + txt + strings.Repeat(" ", totalPad-left) + pad)
+			sb.WriteString(t.cellDivider(i, len(spans)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if !t.innerBorders {
+		return sb.String()
+	}
+
+	// Separator: crosses at group boundaries, tees inside groups
+	sb.WriteString(t.getStyledChar(LeftT))
+	for i, g := range spans {
+		for col := g.Start; col < g.Start+g.Span; col++ {
+			sb.WriteString(t.getStyledHLine(t.paddedWidth(t.columnWidths[col])))
+			if col < g.Start+g.Span-1 {
+				sb.WriteString(t.getStyledChar(TopT))
+			}
+		}
+		if i < len(spans)-1 {
+			sb.WriteString(t.getStyledChar(Cross))
+		}
+	}
+	sb.WriteString(t.getStyledChar(RightT) + "\n")
 	return sb.String()
 }
 
@@ -522,7 +2513,211 @@ func (t *Table) renderMiddleBorder() string {
 	var sb strings.Builder
 	sb.WriteString(t.getStyledChar(LeftT))
 	for i, w := range t.columnWidths {
-		sb.WriteString(t.getStyledHLine(w + 2))
+		sb.WriteString(t.getStyledHLine(t.paddedWidth(w)))
+		if i < len(t.columnWidths)-1 {
+			sb.WriteString(t.getStyledChar(Cross))
+		}
+	}
+	sb.WriteString(t.getStyledChar(RightT) + "\n")
+	return sb.String()
+}
+
+// renderEmptyMessage renders the header separator, a single full-width row
+// with the empty message centered in it, and the bottom border
+func (t *Table) renderEmptyMessage() string {
+	spanned := t.totalWidth() - 2 // Inside the outer borders
+	var sb strings.Builder
+
+	if t.innerBorders {
+		sb.WriteString(t.getStyledChar(LeftT))
+		for i, w := range t.columnWidths {
+			sb.WriteString(t.getStyledHLine(t.paddedWidth(w)))
+			if i < len(t.columnWidths)-1 {
+				sb.WriteString(t.getStyledChar(BottomT))
+			}
+		}
+		sb.WriteString(t.getStyledChar(RightT) + "\n")
+	}
+
+	for _, line := range t.smartSplitByWords(t.emptyMessage, spanned-2*t.cellPadding) {
+		totalPad := spanned - displayWidth(line)
+		if totalPad < 0 {
+			totalPad = 0
+		}
+		left := totalPad / 2
+		sb.WriteString(t.getStyledChar(VLine))
+		sb.WriteString(strings.Repeat(" ", left) + line + strings.Repeat(" ", totalPad-left))
+		sb.WriteString(t.getStyledChar(VLine) + "\n")
+	}
+
+	sb.WriteString(t.getStyledChar(BottomLeft))
+	sb.WriteString(t.getStyledHLine(spanned))
+	sb.WriteString(t.getStyledChar(BottomRight) + "\n")
+	return sb.String()
+}
+
+// renderMoreRows closes the table with a full-width line counting the hidden
+// rows left out by SetMaxDisplayRows. afterDesc reports whether the last
+// rendered row ends with a description block.
+func (t *Table) renderMoreRows(hidden int, afterDesc bool) string {
+	spanned := t.totalWidth() - 2 // Inside the outer borders
+	var sb strings.Builder
+
+	if t.innerBorders {
+		sb.WriteString(t.getStyledChar(LeftT))
+		if afterDesc {
+			if t.descriptionColumn() > 0 {
+				sb.WriteString(t.getStyledHLine(t.paddedWidth(t.columnWidths[0])))
+				sb.WriteString(t.getStyledChar(BottomT))
+			}
+			sb.WriteString(t.getStyledHLine(t.descriptionWidth()))
+		} else {
+			for i, w := range t.columnWidths {
+				sb.WriteString(t.getStyledHLine(t.paddedWidth(w)))
+				if i < len(t.columnWidths)-1 {
+					sb.WriteString(t.getStyledChar(BottomT))
+				}
+			}
+		}
+		sb.WriteString(t.getStyledChar(RightT) + "\n")
+	}
+
+	ellipsis := "…"
+	if t.asciiGrid() {
+		ellipsis = "..."
+	}
+	noun := "rows"
+	if hidden == 1 {
+		noun = "row"
+	}
+	text := fmt.Sprintf("%s %d more %s", ellipsis, hidden, noun)
+	for _, line := range t.smartSplitByWords(text, spanned-2*t.cellPadding) {
+		pad := spanned - t.cellPadding - displayWidth(line)
+		if pad < 0 {
+			pad = 0
+		}
+		sb.WriteString(t.getStyledChar(VLine))
+		sb.WriteString(strings.Repeat(" ", t.cellPadding) + line + strings.Repeat(" ", pad))
+		sb.WriteString(t.getStyledChar(VLine) + "\n")
+	}
+
+	sb.WriteString(t.getStyledChar(BottomLeft))
+	sb.WriteString(t.getStyledHLine(spanned))
+	sb.WriteString(t.getStyledChar(BottomRight) + "\n")
+	return sb.String()
+}
+
+// separatorGlyphs are the characters of a horizontal separator line
+type separatorGlyphs struct {
+	left, hline, cross, down, right string
+}
+
+// separatorStyles are the styles accepted by SetSeparatorBefore
+var separatorStyles = map[string]separatorGlyphs{
+	"double": {"╞", "═", "╪", "╤", "╡"},
+	"bold":   {"┝", "━", "┿", "┯", "┥"},
+}
+
+// SetSeparatorBefore sets the style of the separator drawn above a data row:
+// "double", "bold" or "none". The separator above row 0 is the header/data
+// separator. Unknown styles are ignored.
+func (t *Table) SetSeparatorBefore(row int, style string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := separatorStyles[style]; !ok && style != "none" {
+		return
+	}
+	if row >= 0 {
+		t.separatorsBefore[row] = style
+	}
+}
+
+// SetHeaderSeparator shows/hides the line between the headers and the first
+// data row, for compact tables. Other borders are unaffected.
+func (t *Table) SetHeaderSeparator(show bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.headerSeparator = show
+}
+
+// SetRowSeparators enables/disables the horizontal rule between data rows
+// (enabled by default). Rows ending in a description block are always closed
+// off, and separators styled with SetSeparatorBefore are always drawn.
+func (t *Table) SetRowSeparators(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rowSeparators = enabled
+}
+
+// hasSeparatorBefore checks if a separator is drawn between plain data row
+// ri-1 and row ri
+func (t *Table) hasSeparatorBefore(ri int) bool {
+	_, styled := t.separatorsBefore[ri]
+	return t.rowSeparators || styled
+}
+
+// renderSeparatorBefore renders the separator above data row ri. afterDesc
+// reports whether the previous row ends with a description block, in which
+// case only the first column boundary is a full cross.
+func (t *Table) renderSeparatorBefore(ri int, afterDesc bool) string {
+	if !t.innerBorders {
+		return ""
+	}
+	style, ok := t.separatorsBefore[ri]
+	if !ok {
+		if afterDesc {
+			return t.renderDescToDataBorder()
+		}
+		return t.renderMiddleBorder()
+	}
+	if style == "none" {
+		return ""
+	}
+
+	g := separatorStyles[style]
+	var sb strings.Builder
+	sb.WriteString(t.getStyledChar(g.left))
+	for i, w := range t.columnWidths {
+		sb.WriteString(t.getStyledRepeat(g.hline, t.paddedWidth(w)))
+		if i < len(t.columnWidths)-1 {
+			if afterDesc && i >= t.descriptionColumn() {
+				sb.WriteString(t.getStyledChar(g.down))
+			} else {
+				sb.WriteString(t.getStyledChar(g.cross))
+			}
+		}
+	}
+	sb.WriteString(t.getStyledChar(g.right) + "\n")
+	return sb.String()
+}
+
+// SetHeaderSeparatorBars makes the header/data separator double as a
+// utilization indicator: the segment under each column in ratios is filled
+// with block characters in proportion to its ratio (0 to 1)
+func (t *Table) SetHeaderSeparatorBars(ratios map[int]float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.headerBars = ratios
+}
+
+// renderHeaderBarSeparator renders the header/data separator with each
+// column's segment partially filled according to its ratio
+func (t *Table) renderHeaderBarSeparator() string {
+	var sb strings.Builder
+	sb.WriteString(t.getStyledChar(LeftT))
+	for i, w := range t.columnWidths {
+		width := t.paddedWidth(w)
+		filled := 0
+		if ratio, ok := t.headerBars[i]; ok {
+			ratio = math.Max(0, math.Min(1, ratio))
+			filled = int(math.Round(ratio * float64(width)))
+		}
+		sb.WriteString(t.getStyledRepeat("█", filled))
+		sb.WriteString(t.getStyledHLine(width - filled))
 		if i < len(t.columnWidths)-1 {
 			sb.WriteString(t.getStyledChar(Cross))
 		}
@@ -535,9 +2730,9 @@ func (t *Table) renderBottomBorder() string {
 	var sb strings.Builder
 	sb.WriteString(t.getStyledChar(BottomLeft))
 	for i, w := range t.columnWidths {
-		sb.WriteString(t.getStyledHLine(w + 2))
+		sb.WriteString(t.getStyledHLine(t.paddedWidth(w)))
 		if i < len(t.columnWidths)-1 {
-			sb.WriteString(t.getStyledChar(BottomT))
+			sb.WriteString(t.getInnerChar(BottomT))
 		}
 	}
 	sb.WriteString(t.getStyledChar(BottomRight) + "\n")
@@ -554,6 +2749,42 @@ func detectTerminalWidth() int {
 	return width
 }
 
+// detectANSISupport reports whether output should carry ANSI decorations:
+// a non-empty NO_COLOR disables them, otherwise FORCE_COLOR decides if set
+// ("0" and "false" disable them, any other value enables them), otherwise
+// they are used only when stdout is a terminal
+func detectANSISupport() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	switch strings.ToLower(os.Getenv("FORCE_COLOR")) {
+	case "":
+	case "0", "false":
+		return false
+	default:
+		return true
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// detectBorderChars picks the default grid glyphs from the locale: ASCII
+// when the first non-empty of LC_ALL, LC_CTYPE and LANG names a non-UTF-8
+// locale (e.g. "C"), box drawing characters otherwise
+func detectBorderChars() BorderChars {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+		locale = strings.ToLower(locale)
+		if strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8") {
+			return UnicodeBorderChars
+		}
+		return ASCIIBorderChars
+	}
+	return UnicodeBorderChars
+}
+
 func RapidFortTable(headers []string) *Table {
 	// Create a copy of the headers slice to avoid modifying the original
 	headersCopy := make([]string, len(headers))
@@ -576,11 +2807,46 @@ func NewTable(headers []string) *Table {
 		consoleWidth:       termWidth,
 		fillWidth:          false, // Change default to false - don't fill width unnecessarily
 		dimBorder:          true,
-		supportANSI:        term.IsTerminal(int(os.Stdout.Fd())),
+		supportANSI:        detectANSISupport(),
 		maxWidths:          make(map[int]int),
+		minWidths:          make(map[int]int),
+		columnSuffixes:     make(map[int]string),
+		cellAlignments:     make(map[[2]int]string),
+		abbreviations:      make(map[int]map[string]string),
+		fixedWidths:        make(map[int]int),
+		widthPercents:      make(map[int]float64),
+		hashColumns:        make(map[int][]int),
+		columnFormatters:   make(map[int]func(string) string),
+		decimalColumns:     make(map[int]bool),
+		protectedColumns:   make(map[int]bool),
+		barColumns:         make(map[int]float64),
+		separatorsBefore:   make(map[int]string),
+		collators:          make(map[int]*collate.Collator),
+		explicitAlignments: make(map[int]bool),
 		highlightHeaders:   true,    // Always highlight headers by default
 		highlightedHeaders: []int{}, // Initialize the highlighted headers slice
+		highlightedCells:   make(map[[2]int]bool),
+		highlightedRows:    make(map[int]bool),
+		rowColors:          make(map[int]string),
+		columnColors:       make(map[int]string),
 		rowCountEnabled:    false,
+		rowCountHeader:     "#",
+		rowCountStart:      1,
+		verticalAlignment:  "top",
+		cellPadding:        padding,
+		tabWidth:           defaultTabWidth,
+		wrapDelimiters:     []string{defaultWrapDelimiter},
+		showHeader:         true,
+		showDescriptions:   true,
+		descriptionStyles:  make(map[[2]int]descriptionStyle),
+		treeDepths:         make(map[int]int),
+		visibleCache:       make(map[string]visibleText),
+		mu:                 new(tableMutex),
+		innerBorders:       true,
+		borderChars:        detectBorderChars(),
+		rowSeparators:      true,
+		headerSeparator:    true,
+		defaultMaxWidth:    maxColumnWidth,
 	}
 
 	if !table.supportANSI {
@@ -596,18 +2862,26 @@ func NewTable(headers []string) *Table {
 	return table
 }
 
-// smartSplitByWords splits text by words to fit within maxWidth
+// smartSplitByWords splits text by words to fit within maxWidth display
+// columns
 func (t *Table) smartSplitByWords(text string, maxWidth int) []string {
 	// Strip ANSI for width calculation, but keep original for output
-	textVisible := stripANSI(text)
+	textVisible := t.visible(text).text
 
 	// If the text already fits, no need to split
-	if utf8.RuneCountInString(textVisible) <= maxWidth {
+	if displayWidth(textVisible) <= maxWidth {
 		return []string{text}
 	}
 
-	// Extract ANSI prefix/suffix if any
+	// Extract ANSI prefix/suffix if any. With codes inside the content the
+	// wrapper only opens the first line and closes the last, and carrySGR
+	// carries the styles over the breaks.
 	prefix, suffix, visibleContent := extractWrappingANSI(text)
+	outerPrefix, outerSuffix := prefix, suffix
+	inner := ansiRegexp.MatchString(visibleContent)
+	if inner {
+		prefix, suffix = "", ""
+	}
 
 	// Split the visible content by spaces
 	words := strings.Fields(visibleContent)
@@ -630,7 +2904,7 @@ func (t *Table) smartSplitByWords(text string, maxWidth int) []string {
 		}
 		testLineVisible += wordVisible
 
-		if utf8.RuneCountInString(testLineVisible) <= maxWidth {
+		if displayWidth(testLineVisible) <= maxWidth {
 			// Word fits on current line
 			if currentLine != "" {
 				currentLine += " "
@@ -644,21 +2918,9 @@ func (t *Table) smartSplitByWords(text string, maxWidth int) []string {
 			}
 
 			// If the word itself is too long, split it
-			if utf8.RuneCountInString(wordVisible) > maxWidth {
+			if displayWidth(wordVisible) > maxWidth {
 				// Create chunks of the word that fit
-				var chunks []string
-				remaining := wordVisible
-				for utf8.RuneCountInString(remaining) > 0 {
-					chunkSize := maxWidth
-					if utf8.RuneCountInString(remaining) <= chunkSize {
-						chunks = append(chunks, remaining)
-						break
-					}
-
-					chunk := remaining[:chunkSize]
-					chunks = append(chunks, chunk)
-					remaining = remaining[chunkSize:]
-				}
+				chunks := t.chunkWord(wordVisible, maxWidth)
 
 				// Add chunks as separate lines
 				for i, chunk := range chunks {
@@ -683,7 +2945,11 @@ func (t *Table) smartSplitByWords(text string, maxWidth int) []string {
 		result = append(result, prefix+currentLine+suffix)
 	}
 
-	return result
+	if inner && len(result) > 0 {
+		result[0] = outerPrefix + result[0]
+		result[len(result)-1] += outerSuffix
+	}
+	return carrySGR(result)
 }
 
 // calculateOptimalColumnWidths distributes width to each column based on content
@@ -695,7 +2961,7 @@ func (t *Table) calculateOptimalColumnWidths(maxWidth int) {
 	totalRequiredWidth := 1 // Start with left border
 	for _, w := range t.columnWidths {
 		// Add column width + padding + separator
-		totalRequiredWidth += w + 2 + 1
+		totalRequiredWidth += t.paddedWidth(w) + 1
 	}
 
 	// If total width exceeds available width, redistribute
@@ -712,43 +2978,110 @@ func (t *Table) calculateOptimalColumnWidths(maxWidth int) {
 
 // shrinkColumnsToFit reduces column widths to fit within available space
 func (t *Table) shrinkColumnsToFit(excessWidth int) {
+	if t.shrinkStrategy == ShrinkProportional {
+		excessWidth = t.shrinkProportionally(excessWidth)
+	}
+
 	// Start by reducing the widest columns first
 	for excessWidth > 0 {
 		// Find the widest column that can be shrunk
-		maxWidth, idx := 0, -1
-		for i, w := range t.columnWidths {
-			// Don't shrink below minimum usable width (3 chars)
-			if w > maxWidth && w > 3 {
-				maxWidth, idx = w, i
+		idx := t.widestShrinkableColumn()
+		if idx < 0 {
+			// No more columns can be shrunk, we'll have to live with horizontal scrolling
+			break
+		}
+
+		// Reduce the column width
+		reduceBy := 1
+		if excessWidth > 5 && t.columnWidths[idx] > 10 {
+			// For large excesses, reduce by more to avoid many small reductions
+			reduceBy = excessWidth / 5
+			if slack := t.columnWidths[idx] - t.minColumnWidth(idx); reduceBy > slack {
+				reduceBy = slack
+			}
+		}
+
+		t.columnWidths[idx] -= reduceBy
+		excessWidth -= reduceBy
+	}
+}
+
+// isExpandable checks if a column may receive extra width in fillWidth mode
+func (t *Table) isExpandable(col int) bool {
+	if t.isPinned(col) {
+		return false
+	}
+	if len(t.flexColumns) > 0 && !t.flexColumns[col] {
+		return false
+	}
+	maxWidth, exists := t.maxWidths[col]
+	return !exists || t.columnWidths[col] < maxWidth
+}
+
+// SetExpandStrategy sets how extra width is shared out among the expandable
+// columns in fillWidth mode
+func (t *Table) SetExpandStrategy(s ExpandStrategy) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.expandStrategy = s
+}
+
+// expandProportionally grows the expandable columns by extraWidth in total,
+// each in proportion to its width before growing. Width a column can't take
+// past its max width is shared out again among the others.
+func (t *Table) expandProportionally(extraWidth int) {
+	base := slices.Clone(t.columnWidths)
+	for extraWidth > 0 {
+		total, widest := 0, -1
+		for i, w := range base {
+			if t.isExpandable(i) {
+				total += w
+				if widest < 0 || w > base[widest] {
+					widest = i
+				}
 			}
 		}
+		if widest < 0 {
+			return
+		}
 
-		if idx < 0 {
-			// No more columns can be shrunk, we'll have to live with horizontal scrolling
-			break
+		grow := make([]int, len(base))
+		added := 0
+		for i, w := range base {
+			if t.isExpandable(i) && total > 0 {
+				grow[i] = extraWidth * w / total
+				added += grow[i]
+			}
 		}
+		// The rounding leftover goes to the widest column
+		grow[widest] += extraWidth - added
 
-		// Reduce the column width
-		reduceBy := 1
-		if excessWidth > 5 && t.columnWidths[idx] > 10 {
-			// For large excesses, reduce by more to avoid many small reductions
-			reduceBy = excessWidth / 5
-			if reduceBy > (t.columnWidths[idx] - 3) {
-				reduceBy = t.columnWidths[idx] - 3
+		for i, g := range grow {
+			// Pinned and full columns neither grow nor give up their width
+			if g <= 0 || !t.isExpandable(i) {
+				continue
 			}
+			if maxWidth, exists := t.maxWidths[i]; exists && t.columnWidths[i]+g > maxWidth {
+				g = maxWidth - t.columnWidths[i]
+			}
+			t.columnWidths[i] += g
+			extraWidth -= g
 		}
-
-		t.columnWidths[idx] -= reduceBy
-		excessWidth -= reduceBy
 	}
 }
 
 // expandColumnsToFit distributes extra space among columns
 func (t *Table) expandColumnsToFit(extraWidth int) {
+	if t.expandStrategy == ExpandProportional {
+		t.expandProportionally(extraWidth)
+		return
+	}
+
 	// Count expandable columns (exclude those with max width constraints)
 	expandableCols := 0
 	for i := range t.columnWidths {
-		if maxWidth, exists := t.maxWidths[i]; !exists || t.columnWidths[i] < maxWidth {
+		if t.isExpandable(i) {
 			expandableCols++
 		}
 	}
@@ -760,7 +3093,7 @@ func (t *Table) expandColumnsToFit(extraWidth int) {
 		expandedCount := 0
 		for i := range t.columnWidths {
 			// Skip columns that have reached their max width
-			if maxWidth, exists := t.maxWidths[i]; exists && t.columnWidths[i] >= maxWidth {
+			if !t.isExpandable(i) {
 				continue
 			}
 
@@ -784,201 +3117,347 @@ func (t *Table) prepareWithRowCount() *Table {
 		return t
 	}
 
-	// Create a new table with row counts
-	newHeaders := append([]string{"#"}, t.Headers...)
-	newTable := NewTable(newHeaders)
-
-	// Copy properties from original table
-	newTable.Descriptions = t.Descriptions
-	newTable.DescriptionTitles = t.DescriptionTitles
-	newTable.consoleWidth = t.consoleWidth
-	newTable.fillWidth = t.fillWidth
-	newTable.dimBorder = t.dimBorder
-	newTable.supportANSI = t.supportANSI
-	newTable.borderless = t.borderless
-	newTable.highlightHeaders = t.highlightHeaders
-	newTable.highlightedHeaders = t.highlightedHeaders
+	// Start from a copy of the table, so that every setting carries over and
+	// terminal detection isn't re-run, then make room for the number column
+	newTable := t.clone()
 	newTable.group = t.group
+	// The caller owns t exclusively, so the copy can measure into its cache
+	newTable.visibleCache = t.visibleCache
 	newTable.rowCountEnabled = false // Prevent infinite recursion
 
-	// Copy alignments
-	newTable.alignments = make([]string, len(newHeaders))
-	newTable.alignments[0] = "right" // Right-align row numbers
-	for i := 0; i < len(t.alignments); i++ {
-		newTable.alignments[i+1] = t.alignments[i]
+	newTable.Headers = append([]string{t.rowCountHeader}, t.Headers...)
+	newTable.Rows = make([][]string, len(t.Rows))
+	for i, row := range t.Rows {
+		rowNum := fmt.Sprintf("%d", i+t.rowCountStart)
+		newTable.Rows[i] = append([]string{rowNum}, t.fitRow(row)...)
+	}
+
+	// Right-align row numbers
+	newTable.alignments = append([]string{"right"}, t.alignments...)
+	newTable.explicitAlignments = shiftColumns(t.explicitAlignments)
+	newTable.explicitAlignments[0] = true
+
+	// Grouped tables keep their synced widths; the number column fits the
+	// widest number or its header
+	newTable.columnWidths = make([]int, len(newTable.Headers))
+	if t.group != nil && len(t.columnWidths) == len(t.Headers) {
+		last := fmt.Sprintf("%d", len(t.Rows)-1+t.rowCountStart)
+		newTable.columnWidths[0] = max(len(last), displayWidth(t.rowCountHeader))
+		copy(newTable.columnWidths[1:], t.columnWidths)
+	}
+
+	// Shift everything keyed by column
+	newTable.highlightedHeaders = make([]int, len(t.highlightedHeaders))
+	for i, col := range t.highlightedHeaders {
+		newTable.highlightedHeaders[i] = col + 1
+	}
+	for i := range newTable.headerGroups {
+		newTable.headerGroups[i].Start++
+	}
+	newTable.maxWidths = shiftColumns(t.maxWidths)
+	newTable.minWidths = shiftColumns(t.minWidths)
+	newTable.fixedWidths = shiftColumns(t.fixedWidths)
+	newTable.widthPercents = shiftColumns(t.widthPercents)
+	newTable.flexColumns = shiftColumns(t.flexColumns)
+	newTable.protectedColumns = shiftColumns(t.protectedColumns)
+	newTable.columnSuffixes = shiftColumns(t.columnSuffixes)
+	newTable.abbreviations = shiftColumns(t.abbreviations)
+	newTable.columnFormatters = shiftColumns(t.columnFormatters)
+	newTable.decimalColumns = shiftColumns(t.decimalColumns)
+	newTable.barColumns = shiftColumns(t.barColumns)
+	newTable.columnColors = shiftColumns(t.columnColors)
+	newTable.headerBars = shiftColumns(t.headerBars)
+	newTable.collators = shiftColumns(t.collators)
+	if t.treeColumn >= 0 {
+		newTable.treeColumn = t.treeColumn + 1
+	}
+	newTable.hashColumns = make(map[int][]int, len(t.hashColumns))
+	for col, sources := range t.hashColumns {
+		shifted := make([]int, len(sources))
+		for i, src := range sources {
+			shifted[i] = src + 1
+		}
+		newTable.hashColumns[col+1] = shifted
 	}
-
-	// Copy max widths
-	for col, width := range t.maxWidths {
-		newTable.maxWidths[col+1] = width
+	newTable.cellAlignments = make(map[[2]int]string, len(t.cellAlignments))
+	for cell, alignment := range t.cellAlignments {
+		newTable.cellAlignments[[2]int{cell[0], cell[1] + 1}] = alignment
+	}
+	newTable.highlightedCells = make(map[[2]int]bool, len(t.highlightedCells))
+	for cell := range t.highlightedCells {
+		newTable.highlightedCells[[2]int{cell[0], cell[1] + 1}] = true
 	}
 
-	// Add rows with row numbers
-	for i, row := range t.Rows {
-		rowNum := fmt.Sprintf("%d", i+1)
-		newTable.AddRow(append([]string{rowNum}, row...))
+	if t.cellFormatter != nil {
+		// Leave the row number column unformatted
+		newTable.cellFormatter = func(row, col int, value string) string {
+			if col == 0 {
+				return value
+			}
+			return t.cellFormatter(row, col-1, value)
+		}
+	}
+	if t.dimRowIf != nil {
+		// Hide the row number column from the predicate
+		newTable.dimRowIf = func(row []string) bool { return t.dimRowIf(row[1:]) }
 	}
 
 	return newTable
 }
 
-func (t *Table) Render() string {
-	// 1) If stdout isn't a real terminal, drop ALL ANSI and use minimal widths
-	if t.rowCountEnabled {
-		return t.prepareWithRowCount().Render()
+// shiftColumns returns a copy of a column-keyed map with every column moved
+// one to the right, to make room for the row number column
+func shiftColumns[V any](m map[int]V) map[int]V {
+	shifted := make(map[int]V, len(m))
+	for col, v := range m {
+		shifted[col+1] = v
 	}
+	return shifted
+}
 
+// computeColumnWidths sets the column widths used for rendering
+func (t *Table) computeColumnWidths() {
 	if !t.supportANSI {
-		// Disable ANSI-based decorations
-		t.dimBorder = false
-		t.highlightHeaders = false
-
-		// Strip ANSI from headers
-		for i, h := range t.Headers {
-			t.Headers[i] = stripANSI(h)
-		}
-		// Strip ANSI from every table cell
-		for ri, row := range t.Rows {
-			for ci, cell := range row {
-				t.Rows[ri][ci] = stripANSI(cell)
-			}
-		}
-		// Strip ANSI from every description & title
-		for ri, descs := range t.Descriptions {
-			for i, desc := range descs {
-				t.Descriptions[ri][i] = stripANSI(desc)
-			}
-			if titles, ok := t.DescriptionTitles[ri]; ok {
-				for i, title := range titles {
-					t.DescriptionTitles[ri][i] = stripANSI(title)
-				}
-			}
+		// Compute the absolute minimal column widths, unless the table's
+		// group has synced them
+		if t.group == nil || t.group.columnWidths == nil || len(t.columnWidths) != len(t.Headers) {
+			t.calculateInitialColumnWidths()
 		}
-		// Compute the absolute minimal column widths
-		t.calculateInitialColumnWidths()
-
+	} else if t.group == nil {
+		// ANSI-capable (TTY) mode: use the optimal-width logic
+		t.calculateOptimalColumnWidths(t.consoleWidth)
 	} else {
-		// 2) ANSI-capable (TTY) mode: use your existing optimal-width logic
-		if t.group == nil {
-			t.calculateOptimalColumnWidths(t.consoleWidth)
-		} else {
-			t.adjustColumnWidthsToFit()
-		}
+		// Grouped tables keep their synced widths, adjusted to the console
+		t.adjustColumnWidthsToFit()
+	}
+	t.reserveDescriptionWidth()
+	if t.exactWidth > 0 {
+		t.fitExactWidth(t.exactWidth)
+	}
+}
+
+// SetExactWidth forces the rendered table, borders included, to be exactly n
+// columns wide regardless of content and console width: columns are shrunk
+// or expanded (with the SetShrinkStrategy and SetExpandStrategy strategies)
+// as needed. Width no expandable column can take goes to the last column,
+// past any SetMaxWidth cap; a table whose columns can't shrink enough stays
+// wider than n. n <= 0 restores content-based sizing.
+func (t *Table) SetExactWidth(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.exactWidth = n
+}
+
+// fitExactWidth shrinks or expands the columns to a total width of n
+func (t *Table) fitExactWidth(n int) {
+	total := t.totalWidth()
+	if total > n {
+		t.shrinkColumnsToFit(total - n)
+		return
+	}
+	if total < n {
+		t.expandColumnsToFit(n - total)
+		// Width no expandable column could take goes to the last column
+		t.columnWidths[len(t.columnWidths)-1] += n - t.totalWidth()
 	}
+}
+
+// RowCount returns the number of data rows
+func (t *Table) RowCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return len(t.Rows)
+}
+
+// ColumnCount returns the number of columns
+func (t *Table) ColumnCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return len(t.Headers)
+}
+
+// GetColumnWidths computes the column content widths as Render would and
+// returns a copy of them
+func (t *Table) GetColumnWidths() []int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.computeColumnWidths()
+	widths := make([]int, len(t.columnWidths))
+	copy(widths, t.columnWidths)
+	return widths
+}
+
+// Render renders the table as a string. If render limits are set and exceeded,
+// rendering stops at the row that crossed them; use RenderErr to detect this.
+func (t *Table) Render() string {
+	t.mu.lockForRender()
+	defer t.mu.Unlock()
 
 	var sb strings.Builder
+	_ = t.render(&sb)
+	return sb.String()
+}
 
-	// Top border
-	sb.WriteString(t.renderTopBorder())
+// RenderErr renders the table like Render, but returns an error wrapping
+// ErrRenderLimitExceeded instead of the output if the render limits are exceeded
+func (t *Table) RenderErr() (string, error) {
+	t.mu.lockForRender()
+	defer t.mu.Unlock()
 
-	// Headers
-	headerLines := make([][]string, len(t.Headers))
-	for i, h := range t.Headers {
-		headerLines[i] = t.smartSplitCellContent(h, i)
+	var sb strings.Builder
+	if err := t.render(&sb); err != nil {
+		return "", err
 	}
-	maxH := 0
-	for _, lines := range headerLines {
-		if len(lines) > maxH {
-			maxH = len(lines)
-		}
+	return sb.String(), nil
+}
+
+// RenderTo renders the table like Render and writes it to w, returning any
+// write error
+func (t *Table) RenderTo(w io.Writer) error {
+	_, err := w.Write(t.RenderBytes())
+	return err
+}
+
+// RenderBytes renders the table like Render, returning the output as bytes
+// for writers and sinks that take a []byte
+func (t *Table) RenderBytes() []byte {
+	t.mu.lockForRender()
+	defer t.mu.Unlock()
+
+	var buf bytes.Buffer
+	_ = t.render(&buf)
+	return buf.Bytes()
+}
+
+// Print renders the table to os.Stdout
+func (t *Table) Print() error {
+	return t.RenderTo(os.Stdout)
+}
+
+// render draws the table to w, which Render and RenderBytes back with a
+// strings.Builder and a bytes.Buffer. Output stops at the row that crosses
+// the render limits, with an error wrapping ErrRenderLimitExceeded.
+func (t *Table) render(w io.StringWriter) error {
+	// Collapsed and numbered tables are drawn from a prepared copy
+	if t.collapseDuplicates || t.rowCountEnabled {
+		return t.preparedTable().render(w)
 	}
 
-	for line := 0; line < maxH; line++ {
-		sb.WriteString(t.getStyledChar(VLine))
-		for ci := range t.Headers {
-			txt := ""
-			if line < len(headerLines[ci]) {
-				txt = headerLines[ci][line]
-			}
-			highlighted := t.getHighlightedText(txt, ci)
-			sb.WriteString(t.formatCellContent(highlighted, ci))
-			sb.WriteString(t.getStyledChar(VLine))
-		}
-		sb.WriteString("\n")
+	// Leave out the rows past the display limit, after numbering them
+	hidden := 0
+	if t.maxDisplayRows > 0 && len(t.Rows) > t.maxDisplayRows {
+		hidden = len(t.Rows) - t.maxDisplayRows
+		rows := t.Rows
+		t.Rows = t.Rows[:t.maxDisplayRows]
+		defer func() { t.Rows = rows }()
+	}
+
+	if !t.supportANSI {
+		t.stripANSIContent()
+	}
+
+	// Tree connectors count towards the width of their column
+	t.treePrefixes = t.treeConnectors()
+	defer func() { t.treePrefixes = nil }()
+
+	t.layout()
+
+	out := &renderLimiter{w: w, maxLines: t.maxRenderLines, maxBytes: t.maxRenderBytes}
+
+	// Title
+	out.WriteString(t.renderTitle())
+
+	// Top border, preceded by the header group row if any
+	if len(t.headerGroups) > 0 {
+		out.WriteString(t.renderHeaderGroups())
+	} else {
+		out.WriteString(t.renderTopBorder())
+	}
+
+	// Headers
+	out.WriteString(t.renderHeaderRows())
+
+	// Placeholder for a table without data rows
+	if len(t.Rows) == 0 && t.emptyMessage != "" {
+		out.WriteString(t.renderEmptyMessage())
+		out.WriteString(t.renderFooter())
+		return out.check()
 	}
 
 	// Header/Data separator
-	sb.WriteString(t.renderMiddleBorder())
+	out.WriteString(t.renderHeaderSeparator())
 
 	// Rows + Descriptions
 	for ri, row := range t.Rows {
-		// Data row
-		rowLines := make([][]string, len(row))
-		maxR := 0
-		for ci, cell := range row {
-			rowLines[ci] = t.smartSplitCellContent(cell, ci)
-			if len(rowLines[ci]) > maxR {
-				maxR = len(rowLines[ci])
-			}
-		}
+		// Rows assigned directly may not match the headers
+		row = t.fitRow(row)
 
-		for line := 0; line < maxR; line++ {
-			sb.WriteString(t.getStyledChar(VLine))
-			for ci := range row {
-				txt := ""
-				if line < len(rowLines[ci]) {
-					txt = rowLines[ci][line]
-				}
-				sb.WriteString(t.formatCellContent(txt, ci))
-				sb.WriteString(t.getStyledChar(VLine))
-			}
-			sb.WriteString("\n")
-		}
+		// Data row
+		out.WriteString(t.renderDataRow(ri, row))
 
 		// Optional description blocks
-		if descs, ok := t.Descriptions[ri]; ok && len(descs) > 0 {
-			// Compute merged width of columns 2..n (used by all descriptions)
-			mergedWidth := 0
-			for i := 1; i < len(t.columnWidths); i++ {
-				mergedWidth += t.columnWidths[i] + 2
-				if i < len(t.columnWidths)-1 {
-					mergedWidth += 1
-				}
-			}
+		if descs := t.rowDescriptions(ri); len(descs) > 0 {
+			// Merged width of the columns the descriptions span
+			mergedWidth := t.descriptionWidth()
+			first := t.descriptionColumn()
 
 			// Process each description for this row
 			for di, desc := range descs {
 				// Top border of description block (first one) or separator between descriptions
-				if di == 0 {
-					// First description - top border
-					sb.WriteString(t.getStyledChar(VLine))
-					sb.WriteString(t.formatCellContent("", 0))
-					sb.WriteString(t.getStyledChar(LeftT))
-					for i := 1; i < len(t.columnWidths); i++ {
-						sb.WriteString(t.getStyledHLine(t.columnWidths[i] + 2))
-						if i < len(t.columnWidths)-1 {
-							sb.WriteString(t.getStyledChar(BottomT))
+				// (neither is drawn without inner borders)
+				if t.innerBorders {
+					if di == 0 {
+						// First description - top border
+						out.WriteString(t.descriptionGutter(ri, LeftT))
+						for i := first; i < len(t.columnWidths); i++ {
+							out.WriteString(t.getStyledHLine(t.paddedWidth(t.columnWidths[i])))
+							if i < len(t.columnWidths)-1 {
+								out.WriteString(t.getStyledChar(BottomT))
+							}
 						}
+						out.WriteString(t.getStyledChar(RightT) + "\n")
+					} else {
+						// Separator between descriptions
+						out.WriteString(t.descriptionGutter(ri, LeftT))
+						// For inter-description separators, we don't want column divisions
+						out.WriteString(t.getStyledHLine(mergedWidth))
+						out.WriteString(t.getStyledChar(RightT) + "\n")
 					}
-					sb.WriteString(t.getStyledChar(RightT) + "\n")
-				} else {
-					// Separator between descriptions
-					sb.WriteString(t.getStyledChar(VLine))
-					sb.WriteString(t.formatCellContent("", 0))
-					sb.WriteString(t.getStyledChar(LeftT))
-					// For inter-description separators, we don't want column divisions
-					sb.WriteString(t.getStyledHLine(mergedWidth))
-					sb.WriteString(t.getStyledChar(RightT) + "\n")
 				}
 
-				// Description title (if any)
+				// Description title (if any), wrapped to stay inside the box
 				if titles, ok := t.DescriptionTitles[ri]; ok && di < len(titles) && titles[di] != "" {
-					headerText := " [ " + BoldStyleStart + titles[di] + BoldStyleEnd + " ]"
-					pad := mergedWidth - utf8.RuneCountInString(stripANSI(headerText))
-					if pad < 0 {
-						pad = 0
-					}
+					titleLines := t.smartSplitByWords(titles[di], mergedWidth-5)
+					for i, line := range titleLines {
+						if t.supportANSI {
+							line = BoldStyleStart + line + BoldStyleEnd
+						}
+						headerText := "   " + line
+						if i == 0 {
+							headerText = " [ " + line
+						}
+						if i == len(titleLines)-1 {
+							headerText += " ]"
+						}
+						pad := mergedWidth - displayWidth(headerText)
+						if pad < 0 {
+							pad = 0
+						}
 
-					sb.WriteString(t.getStyledChar(VLine))
-					sb.WriteString(t.formatCellContent("", 0))
-					sb.WriteString(t.getStyledChar(VLine))
-					sb.WriteString(headerText)
-					sb.WriteString(strings.Repeat(" ", pad))
-					sb.WriteString(t.getStyledChar(VLine) + "\n")
+						out.WriteString(t.descriptionGutter(ri, VLine))
+						out.WriteString(headerText)
+						out.WriteString(strings.Repeat(" ", pad))
+						out.WriteString(t.getStyledChar(VLine) + "\n")
+					}
 				}
 
 				// Split into bullet points
 				bps := strings.Split(desc, "\n")
+				style := t.descriptionStyles[[2]int{ri, di}]
 
 				// Bullet lines
 				for _, bp := range bps {
@@ -986,111 +3465,325 @@ func (t *Table) Render() string {
 					if bp == "" {
 						continue
 					}
-					prefix := " "
-					textWidth := mergedWidth - utf8.RuneCountInString(prefix) - 2
-					if textWidth < 0 {
-						textWidth = 0
-					}
+					prefix := " " + strings.Repeat(" ", t.descriptionIndent) + t.descriptionBullet
+					textWidth := mergedWidth - displayWidth(prefix) - 2
 					wrapped := t.smartSplitByWords(bp, textWidth)
 
 					for i, wline := range wrapped {
-						sb.WriteString(t.getStyledChar(VLine))
-						sb.WriteString(t.formatCellContent("", 0))
-						sb.WriteString(t.getStyledChar(VLine))
+						out.WriteString(t.descriptionGutter(ri, VLine))
 
-						var disp string
-						if i == 0 {
-							disp = prefix + wline
-						} else {
-							indent := strings.Repeat(" ", utf8.RuneCountInString(prefix))
-							disp = indent + wline
+						// Continuation lines align under the text, not the bullet
+						lead := prefix
+						if i > 0 {
+							lead = strings.Repeat(" ", displayWidth(prefix))
+						}
+						// Free space beside the text, short of a one-column margin
+						free := max(mergedWidth-1-displayWidth(lead)-displayWidth(wline), 0)
+						switch style.alignment {
+						case "right":
+							lead += strings.Repeat(" ", free)
+						case "center":
+							lead += strings.Repeat(" ", free/2)
 						}
-						pad := mergedWidth - utf8.RuneCountInString(stripANSI(disp))
+						if style.color != "" && t.supportANSI {
+							wline = style.color + strings.ReplaceAll(wline, "\x1b[0m", "\x1b[0m"+style.color) + "\x1b[0m"
+						}
+						disp := lead + wline
+						pad := mergedWidth - displayWidth(disp)
 						if pad < 0 {
 							pad = 0
 						}
-						sb.WriteString(disp)
-						sb.WriteString(strings.Repeat(" ", pad))
-						sb.WriteString(t.getStyledChar(VLine) + "\n")
+						out.WriteString(disp)
+						out.WriteString(strings.Repeat(" ", pad))
+						out.WriteString(t.getStyledChar(VLine) + "\n")
 					}
 				}
 			}
 
-			// Decide which border to draw next
-			isLast := ri == len(t.Rows)-1
-			nextHasDesc := !isLast && (func() bool {
-				_, ok2 := t.Descriptions[ri+1]
-				return ok2 && len(t.Descriptions[ri+1]) > 0
-			})()
-
-			if isLast {
+			if ri == len(t.Rows)-1 && hidden > 0 {
+				out.WriteString(t.renderMoreRows(hidden, true))
+			} else if t.repeatsHeaderBefore(ri + 1) {
+				out.WriteString(t.renderRepeatedHeader(true))
+			} else if ri == len(t.Rows)-1 {
 				// Bottom border after last desc
-				sb.WriteString(t.getStyledChar(BottomLeft))
-				sb.WriteString(t.getStyledHLine(t.columnWidths[0] + 2))
-				sb.WriteString(t.getStyledChar(BottomT))
-				sb.WriteString(t.getStyledHLine(mergedWidth))
-				sb.WriteString(t.getStyledChar(BottomRight) + "\n")
-			} else if nextHasDesc {
-				sb.WriteString(t.renderDescToDataBorder())
-			} else {
-				// Normal separator into next data row
-				sb.WriteString(t.getStyledChar(LeftT))
-				sb.WriteString(t.getStyledHLine(t.columnWidths[0] + 2))
-				sb.WriteString(t.getStyledChar(Cross))
-				for i := 1; i < len(t.columnWidths); i++ {
-					sb.WriteString(t.getStyledHLine(t.columnWidths[i] + 2))
-					if i < len(t.columnWidths)-1 {
-						sb.WriteString(t.getStyledChar(TopT))
-					}
+				out.WriteString(t.getStyledChar(BottomLeft))
+				if first > 0 {
+					out.WriteString(t.getStyledHLine(t.paddedWidth(t.columnWidths[0])))
+					out.WriteString(t.getInnerChar(BottomT))
 				}
-				sb.WriteString(t.getStyledChar(RightT) + "\n")
+				out.WriteString(t.getStyledHLine(mergedWidth))
+				out.WriteString(t.getStyledChar(BottomRight) + "\n")
+			} else {
+				out.WriteString(t.renderSeparatorBefore(ri+1, true))
 			}
-		} else if ri < len(t.Rows)-1 {
+		} else if t.repeatsHeaderBefore(ri + 1) {
+			out.WriteString(t.renderRepeatedHeader(false))
+		} else if ri < len(t.Rows)-1 && t.hasSeparatorBefore(ri+1) {
 			// No description, normal middle border
-			sb.WriteString(t.renderMiddleBorder())
+			out.WriteString(t.renderSeparatorBefore(ri+1, false))
+		}
+
+		// Stop early rather than building an enormous string
+		if err := out.check(); err != nil {
+			return err
 		}
 	}
 
 	// Bottom border if last row had no description
-	if descs, hasDesc := t.Descriptions[len(t.Rows)-1]; !hasDesc || len(descs) == 0 {
-		sb.WriteString(t.renderBottomBorder())
+	if len(t.rowDescriptions(len(t.Rows)-1)) == 0 {
+		if hidden > 0 {
+			out.WriteString(t.renderMoreRows(hidden, false))
+		} else {
+			out.WriteString(t.renderBottomBorder())
+		}
+	}
+
+	// Caption and legend
+	out.WriteString(t.renderFooter())
+
+	return out.check()
+}
+
+// stripANSIContent turns off the ANSI-based decorations and strips ANSI codes
+// from the title, headers, cells and descriptions, for output that can't
+// display them
+func (t *Table) stripANSIContent() {
+	// Disable ANSI-based decorations
+	t.dimBorder = false
+	t.highlightHeaders = false
+
+	// Strip ANSI from title and headers
+	t.title = stripANSI(t.title)
+	t.emptyMessage = stripANSI(t.emptyMessage)
+	for i, h := range t.Headers {
+		t.Headers[i] = stripANSI(h)
+	}
+	// Strip ANSI from every table cell
+	for ri, row := range t.Rows {
+		for ci, cell := range row {
+			t.Rows[ri][ci] = stripANSI(cell)
+		}
+	}
+	// Strip ANSI from every description & title
+	for ri, descs := range t.Descriptions {
+		for i, desc := range descs {
+			t.Descriptions[ri][i] = stripANSI(desc)
+		}
+		if titles, ok := t.DescriptionTitles[ri]; ok {
+			for i, title := range titles {
+				t.DescriptionTitles[ri][i] = stripANSI(title)
+			}
+		}
+	}
+}
+
+// renderHeaderSeparator renders the separator between the headers and the
+// data rows. A hidden header leaves the top border directly above the first
+// row.
+func (t *Table) renderHeaderSeparator() string {
+	if !t.showHeader || !t.innerBorders || !t.headerSeparator {
+		return ""
+	}
+	if len(t.headerBars) > 0 {
+		return t.renderHeaderBarSeparator()
+	}
+	return t.renderSeparatorBefore(0, false)
+}
+
+// SetHeaderRepeat repeats the headers, with their separators, after every n
+// data rows so that long tables stay readable when scrolled. n <= 0 shows
+// the headers only at the top.
+func (t *Table) SetHeaderRepeat(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.headerRepeat = n
+}
+
+// repeatsHeaderBefore checks if the headers are repeated above data row ri
+func (t *Table) repeatsHeaderBefore(ri int) bool {
+	return t.showHeader && t.headerRepeat > 0 && ri < len(t.Rows) && ri%t.headerRepeat == 0
+}
+
+// renderRepeatedHeader renders the border below a data row, the header lines
+// and the header separator. afterDesc reports whether the row ends with a
+// description block.
+func (t *Table) renderRepeatedHeader(afterDesc bool) string {
+	var sb strings.Builder
+	if t.innerBorders {
+		if afterDesc {
+			sb.WriteString(t.renderDescToDataBorder())
+		} else {
+			sb.WriteString(t.renderMiddleBorder())
+		}
+	}
+	sb.WriteString(t.renderHeaderRows())
+	sb.WriteString(t.renderHeaderSeparator())
+	return sb.String()
+}
+
+// renderHeaderRows renders the header lines between the top border and the
+// header separator, or nothing if the header is hidden
+func (t *Table) renderHeaderRows() string {
+	if !t.showHeader {
+		return ""
+	}
+	headerLines := make([][]string, len(t.Headers))
+	for i, h := range t.Headers {
+		headerLines[i] = t.smartSplitCellContent(h, i)
+	}
+	maxH := 0
+	for _, lines := range headerLines {
+		if len(lines) > maxH {
+			maxH = len(lines)
+		}
+	}
+
+	var sb strings.Builder
+	for line := 0; line < maxH; line++ {
+		sb.WriteString(t.getStyledChar(VLine))
+		for ci := range t.Headers {
+			txt := ""
+			if line < len(headerLines[ci]) {
+				txt = headerLines[ci][line]
+			}
+			highlighted := t.getHighlightedText(txt, ci)
+			sb.WriteString(t.formatCellContent(highlighted, -1, ci))
+			sb.WriteString(t.cellDivider(ci, len(t.Headers)))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// renderDataRow renders the lines of data row ri, whose cells must match the
+// headers
+func (t *Table) renderDataRow(ri int, row []string) string {
+	rowLines := make([][]string, len(row))
+	maxR := 0
+	for ci, cell := range row {
+		rowLines[ci] = t.smartSplitCellContent(t.barCell(ci, t.cellDisplayValue(ri, ci, cell)), ci)
+		if len(rowLines[ci]) > maxR {
+			maxR = len(rowLines[ci])
+		}
+	}
+
+	var sb strings.Builder
+	dimmed := t.isDimmedRow(row)
+	for line := 0; line < maxR; line++ {
+		sb.WriteString(t.getStyledChar(VLine))
+		for ci := range row {
+			txt := ""
+			idx := line - t.verticalOffset(len(rowLines[ci]), maxR)
+			if idx >= 0 && idx < len(rowLines[ci]) {
+				txt = rowLines[ci][idx]
+			}
+			txt = t.colorCell(txt, ri, ci)
+			if dimmed && txt != "" {
+				txt = DimStyleStart + txt + DimStyleEnd
+			}
+			if txt != "" && t.isHighlightedCell(ri, ci) {
+				txt = BoldStyleStart + txt + BoldStyleEnd
+			}
+			sb.WriteString(t.shadeRowCell(t.formatCellContent(txt, ri, ci), ri))
+			sb.WriteString(t.cellDivider(ci, len(row)))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// RenderSideBySide syncs the column widths of the group and renders its
+// tables next to each other, gap spaces apart, top-aligned with shorter
+// tables padded by blank lines
+func (g *TableGroup) RenderSideBySide(gap int) string {
+	if gap < 0 {
+		gap = 0
+	}
+	g.SyncColumnWidths()
+
+	blocks := make([][]string, len(g.tables))
+	widths := make([]int, len(g.tables))
+	height := 0
+	for i, table := range g.tables {
+		blocks[i] = strings.Split(strings.TrimSuffix(table.Render(), "\n"), "\n")
+		for _, line := range blocks[i] {
+			if w := displayWidth(line); w > widths[i] {
+				widths[i] = w
+			}
+		}
+		if len(blocks[i]) > height {
+			height = len(blocks[i])
+		}
 	}
 
+	var sb strings.Builder
+	for line := 0; line < height; line++ {
+		var row strings.Builder
+		for i, block := range blocks {
+			txt := ""
+			if line < len(block) {
+				txt = block[line]
+			}
+			row.WriteString(txt)
+			if i < len(blocks)-1 {
+				row.WriteString(strings.Repeat(" ", widths[i]-displayWidth(txt)+gap))
+			}
+		}
+		// Drop the padding left after the last table that still has lines
+		sb.WriteString(strings.TrimRight(row.String(), " ") + "\n")
+	}
 	return sb.String()
 }
 
-// SyncColumnWidths ensures all tables in the group have consistent column widths
+// SyncColumnWidthsE syncs the column widths like SyncColumnWidths, but
+// returns an error and leaves the widths alone if the tables don't all have
+// the same number of columns
+func (g *TableGroup) SyncColumnWidthsE() error {
+	first := 0
+	for i, table := range g.tables {
+		table.mu.RLock()
+		cols := len(table.Headers)
+		table.mu.RUnlock()
+		if i == 0 {
+			first = cols
+		} else if cols != first {
+			return fmt.Errorf("table %d has %d columns, table 0 has %d", i, cols, first)
+		}
+	}
+	g.SyncColumnWidths()
+	return nil
+}
+
+// SyncColumnWidths ensures all tables in the group have consistent column widths.
+// Tables with differing column counts are synced on their leading columns:
+// column i takes the widest width among the tables that have it.
 func (g *TableGroup) SyncColumnWidths() {
 	if len(g.tables) == 0 {
 		return
 	}
 
-	// Initialize with the first table's column count
-	firstTable := g.tables[0]
-	colCount := len(firstTable.Headers)
-
-	// Ensure first table has columnWidths initialized
-	if len(firstTable.columnWidths) != colCount {
-		firstTable.columnWidths = make([]int, colCount)
+	// Initialize the group's column widths for the widest table
+	colCount := 0
+	for _, table := range g.tables {
+		table.mu.RLock()
+		if n := len(table.Headers); n > colCount {
+			colCount = n
+		}
+		table.mu.RUnlock()
 	}
-
-	// Initialize the group's column widths
 	g.columnWidths = make([]int, colCount)
 
 	// Find the maximum width for each column across all tables
 	for _, table := range g.tables {
-		// Ensure each table has columnWidths initialized
-		if len(table.columnWidths) != colCount {
-			table.columnWidths = make([]int, colCount)
-		}
-
+		table.mu.Lock()
 		table.calculateInitialColumnWidths()
 
 		for i := 0; i < colCount && i < len(table.columnWidths); i++ {
 			// Apply maximum column width constraint
 			width := table.columnWidths[i]
-			if width > maxColumnWidth {
-				width = maxColumnWidth
+			if table.defaultMaxWidth > 0 && width > table.defaultMaxWidth {
+				width = table.defaultMaxWidth
 			}
 
 			if width > g.columnWidths[i] {
@@ -1102,28 +3795,90 @@ func (g *TableGroup) SyncColumnWidths() {
 				}
 			}
 		}
+		table.mu.Unlock()
 	}
 
 	// Apply the group's column widths to all tables
 	for _, table := range g.tables {
+		table.mu.Lock()
 		for i := 0; i < colCount && i < len(table.columnWidths); i++ {
 			table.columnWidths[i] = g.columnWidths[i]
 		}
 
 		// Apply any final adjustments needed for console width
 		table.adjustColumnWidthsToFit()
+		table.mu.Unlock()
+	}
+}
+
+// descriptionColumn returns the first column spanned by description blocks:
+// column 0 stays empty beside them unless it is the only column
+func (t *Table) descriptionColumn() int {
+	if len(t.columnWidths) < 2 {
+		return 0
+	}
+	return 1
+}
+
+// descriptionWidth returns the merged width of the columns spanned by
+// description blocks, inside the outer borders
+func (t *Table) descriptionWidth() int {
+	width := 0
+	for i := t.descriptionColumn(); i < len(t.columnWidths); i++ {
+		width += t.paddedWidth(t.columnWidths[i])
+		if i < len(t.columnWidths)-1 {
+			width++
+		}
+	}
+	return width
+}
+
+// descriptionGutter renders the start of a description line up to the merged
+// region: the left border, the empty first column and joint, or just joint
+// in place of the left border for single-column tables
+func (t *Table) descriptionGutter(ri int, joint string) string {
+	if t.descriptionColumn() == 0 {
+		return t.getStyledChar(joint)
+	}
+	sep := t.getStyledChar(joint)
+	if joint == VLine {
+		sep = t.getInnerChar(VLine)
+	}
+	return t.getStyledChar(VLine) + t.formatCellContent("", ri, 0) + sep
+}
+
+// reserveDescriptionWidth widens the last column if the description blocks
+// would be too narrow to hold minDescriptionWidth characters of text
+func (t *Table) reserveDescriptionWidth() {
+	if len(t.columnWidths) == 0 {
+		return
+	}
+	described := false
+	for ri := range t.Descriptions {
+		if len(t.rowDescriptions(ri)) > 0 {
+			described = true
+			break
+		}
+	}
+	// Room for the text plus its leading space, bullet and right margin
+	prefix := t.descriptionIndent + displayWidth(t.descriptionBullet)
+	if deficit := minDescriptionWidth + 3 + prefix - t.descriptionWidth(); described && deficit > 0 {
+		t.columnWidths[len(t.columnWidths)-1] += deficit
 	}
 }
 
 // renderDescToDataBorder draws the border after a description before the next data row,
 // using ANSI-aware dimmed characters.
 func (t *Table) renderDescToDataBorder() string {
+	if t.descriptionColumn() == 0 {
+		return t.renderMiddleBorder()
+	}
 	var sb strings.Builder
 	sb.WriteString(t.getStyledChar(LeftT))
-	sb.WriteString(t.getStyledHLine(t.columnWidths[0] + 2))
+	sb.WriteString(t.getStyledHLine(t.paddedWidth(t.columnWidths[0])))
 	sb.WriteString(t.getStyledChar(Cross))
 	for i := 1; i < len(t.columnWidths); i++ {
-		sb.WriteString(t.getStyledHLine(t.columnWidths[i] + 2))
+		sb.WriteString(t.getStyledHLine(t.paddedWidth(t.columnWidths[i])))
 		if i < len(t.columnWidths)-1 {
 			sb.WriteString(t.getStyledChar(TopT))
 		}
@@ -0,0 +1,2780 @@
+package table
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// newTestTable returns a table whose output doesn't depend on the terminal or
+// the environment the tests run in: no ANSI, 80 columns, box drawing borders
+func newTestTable(headers ...string) *Table {
+	tbl := NewTable(headers)
+	tbl.SetANSISupport(false)
+	tbl.SetConsoleWidth(80)
+	tbl.SetBorderChars(UnicodeBorderChars)
+	return tbl
+}
+
+// renderLines renders tbl and splits the output into lines, without the
+// empty string after the final newline
+func renderLines(tbl *Table) []string {
+	return strings.Split(strings.TrimSuffix(tbl.Render(), "\n"), "\n")
+}
+
+func TestTitleCenteredAboveTable(t *testing.T) {
+	tbl := newTestTable("Name", "Value")
+	tbl.AddRow([]string{"alpha", "1"})
+	tbl.SetTitle("Report")
+
+	lines := renderLines(tbl)
+	if strings.TrimSpace(lines[0]) != "Report" {
+		t.Fatalf("first line = %q, want the title", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "┌") {
+		t.Fatalf("second line = %q, want the top border", lines[1])
+	}
+	left := len(lines[0]) - len(strings.TrimLeft(lines[0], " "))
+	right := DisplayWidth(lines[1]) - left - len("Report")
+	if right-left < 0 || right-left > 1 {
+		t.Errorf("title has %d columns on the left and %d on the right of the box, want it centered", left, right)
+	}
+}
+
+// cells splits a rendered row line into its cells, padding included
+func cells(line string) []string {
+	parts := strings.Split(line, "│")
+	if len(parts) < 3 {
+		return nil
+	}
+	return parts[1 : len(parts)-1]
+}
+
+func TestSuffixedRightAlignedColumnSharesRightEdge(t *testing.T) {
+	tbl := newTestTable("Load", "Host")
+	tbl.SetColumnSuffix(0, "%")
+	tbl.SetAlignment(0, "right")
+	tbl.AddRow([]string{"5", "alpha"})
+	tbl.AddRow([]string{"100", "beta"})
+
+	lines := renderLines(tbl)
+	header := cells(lines[1])[0]
+	edge := len(strings.TrimRight(header, " "))
+	for _, line := range []string{lines[3], lines[5]} {
+		cell := cells(line)[0]
+		if !strings.HasSuffix(strings.TrimSpace(cell), "%") {
+			t.Errorf("cell %q has no %% suffix", cell)
+		}
+		if got := len(strings.TrimRight(cell, " ")); got != edge {
+			t.Errorf("cell %q ends at %d, header %q at %d", cell, got, header, edge)
+		}
+	}
+}
+
+func TestDimRowIfDimsOnlyMatchingRows(t *testing.T) {
+	tbl := newTestTable("Task", "Status")
+	tbl.SetANSISupport(true)
+	tbl.SetDimBorder(false)
+	tbl.AddRow([]string{"deploy", "DONE"})
+	tbl.AddRow([]string{"review", "OPEN"})
+	tbl.AddRow([]string{"release", "DONE"})
+	tbl.SetDimRowIf(func(row []string) bool { return row[1] == "DONE" })
+
+	for _, line := range renderLines(tbl) {
+		dimmed := strings.Contains(line, DimStyleStart)
+		switch {
+		case strings.Contains(line, "DONE") && !dimmed:
+			t.Errorf("DONE row %q is not dimmed", line)
+		case strings.Contains(line, "OPEN") && dimmed:
+			t.Errorf("OPEN row %q is dimmed", line)
+		case !strings.Contains(line, "DONE") && !strings.Contains(line, "OPEN") && dimmed:
+			t.Errorf("line %q is dimmed", line)
+		}
+	}
+}
+
+func TestCellAlignmentOverridesColumn(t *testing.T) {
+	tbl := newTestTable("Amount")
+	tbl.SetAlignment(0, "right")
+	tbl.AddRows([][]string{{"100"}, {"N/A"}, {"250"}})
+	if err := tbl.SetCellAlignment(1, 0, "center"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tbl.SetCellAlignment(1, 0, "middle"); err == nil {
+		t.Error("SetCellAlignment accepted alignment \"middle\"")
+	}
+
+	lines := renderLines(tbl)
+	above, override, below := cells(lines[3])[0], cells(lines[5])[0], cells(lines[7])[0]
+	if above != "    100 " || below != "    250 " {
+		t.Errorf("neighbors = %q, %q, want right-aligned", above, below)
+	}
+	if override != "  N/A   " {
+		t.Errorf("overridden cell = %q, want centered", override)
+	}
+}
+
+func TestColumnAbbreviationsRenderShortForm(t *testing.T) {
+	tbl := newTestTable("Job", "Result")
+	tbl.AddRow([]string{"build", "SUCCEEDED"})
+	tbl.SetColumnAbbreviations(1, map[string]string{"SUCCEEDED": "OK"})
+
+	lines := renderLines(tbl)
+	if got := strings.TrimSpace(cells(lines[3])[1]); got != "OK" {
+		t.Errorf("cell renders %q, want \"OK\"", got)
+	}
+	if strings.Contains(strings.Join(lines, "\n"), "SUCCEEDED") {
+		t.Error("output still contains \"SUCCEEDED\"")
+	}
+	if tbl.Rows[0][1] != "SUCCEEDED" {
+		t.Errorf("stored value = %q, want it unchanged", tbl.Rows[0][1])
+	}
+}
+
+func TestVerticalAlignmentBottom(t *testing.T) {
+	tbl := newTestTable("Short", "Long")
+	tbl.AddRow([]string{"x", "one\ntwo\nthree"})
+	tbl.SetVerticalAlignment("bottom")
+
+	lines := renderLines(tbl)
+	block := lines[3:6]
+	for i, line := range block {
+		short := strings.TrimSpace(cells(line)[0])
+		if i < len(block)-1 && short != "" {
+			t.Errorf("line %d of the row holds %q, want it blank", i, short)
+		}
+		if i == len(block)-1 && short != "x" {
+			t.Errorf("last line of the row holds %q, want \"x\"", short)
+		}
+	}
+	if got := strings.TrimSpace(cells(block[2])[1]); got != "three" {
+		t.Errorf("last line of the long cell = %q, want \"three\"", got)
+	}
+}
+
+func TestColumnWidthPinsShortColumn(t *testing.T) {
+	tbl := newTestTable("ID", "Name")
+	tbl.AddRow([]string{"1", "alpha"})
+	tbl.SetColumnWidth(0, 20)
+
+	lines := renderLines(tbl)
+	// The content width plus one space of padding on each side
+	for _, line := range []string{lines[1], lines[3]} {
+		if w := DisplayWidth(cells(line)[0]); w != 20+2 {
+			t.Errorf("column 0 of %q is %d wide, want 20 plus padding", line, w)
+		}
+	}
+	if !strings.HasPrefix(lines[0], "┌"+strings.Repeat("─", 22)+"┬") {
+		t.Errorf("top border %q doesn't span a 20 column wide cell", lines[0])
+	}
+}
+
+func TestAutoCaptionFollowsTable(t *testing.T) {
+	tbl := newTestTable("Issue", "Summary")
+	tbl.AddRow([]string{"CVE-1", "buffer overflow in the parser"})
+	tbl.SetAutoCaption(map[string]string{"rows": "42", "filter": "critical"})
+
+	lines := renderLines(tbl)
+	bottom := -1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "└") {
+			bottom = i
+		}
+	}
+	if bottom < 0 || bottom == len(lines)-1 {
+		t.Fatalf("no caption after the bottom border:\n%s", strings.Join(lines, "\n"))
+	}
+	caption := strings.Join(lines[bottom+1:], " ")
+	for _, field := range []string{"rows=42", "filter=critical"} {
+		if !strings.Contains(caption, field) {
+			t.Errorf("caption %q is missing %q", caption, field)
+		}
+	}
+}
+
+func TestOverflowEllipsisClipsToOneLine(t *testing.T) {
+	tbl := newTestTable("ID", "Message")
+	tbl.SetMaxWidth(1, 10)
+	tbl.SetOverflow(OverflowEllipsis)
+	tbl.AddRow([]string{"1", "connection reset by peer while reading"})
+
+	lines := renderLines(tbl)
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want the row on a single line:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	cell := cells(lines[3])[1]
+	content := cell[1 : len(cell)-1] // Without the padding
+	if !strings.HasSuffix(content, "…") {
+		t.Errorf("cell %q doesn't end in an ellipsis", content)
+	}
+	if w := DisplayWidth(content); w != 10 {
+		t.Errorf("cell %q is %d wide, want exactly the column width 10", content, w)
+	}
+}
+
+func TestHeaderGroupLabelWidensColumns(t *testing.T) {
+	tbl := newTestTable("In", "Out", "Host")
+	if err := tbl.AddHeaderGroup("Network statistics", 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	tbl.AddRow([]string{"1", "2", "web"})
+
+	lines := renderLines(tbl)
+	label := cells(lines[1])[0]
+	if strings.TrimSpace(label) != "Network statistics" {
+		t.Fatalf("group cell = %q, want the label", label)
+	}
+	left := len(label) - len(strings.TrimLeft(label, " "))
+	right := len(label) - len(strings.TrimRight(label, " "))
+	if left < 1 || right < 1 || right-left < 0 || right-left > 1 {
+		t.Errorf("label %q isn't centered with padding on both sides", label)
+	}
+
+	// The two grouped columns together span the label
+	header := cells(lines[3])
+	spanned := DisplayWidth(header[0]) + 1 + DisplayWidth(header[1])
+	if spanned != DisplayWidth(label) {
+		t.Errorf("grouped columns span %d columns, label cell %d", spanned, DisplayWidth(label))
+	}
+	if DisplayWidth(header[0]) <= len(" In ") || DisplayWidth(header[1]) <= len(" Out ") {
+		t.Errorf("columns %q and %q were not widened", header[0], header[1])
+	}
+}
+
+func TestCollapseDuplicateRowsCountsRun(t *testing.T) {
+	tbl := newTestTable("Level", "Message")
+	for range 3 {
+		tbl.AddRow([]string{"WARN", "disk almost full"})
+	}
+	tbl.AddRow([]string{"INFO", "rotated logs"})
+	tbl.CollapseDuplicateRows(true)
+
+	var rows []string
+	for _, line := range renderLines(tbl) {
+		if c := cells(line); c != nil && !strings.Contains(line, "Level") {
+			rows = append(rows, strings.TrimSpace(c[1]))
+		}
+	}
+	want := []string{"disk almost full (×3)", "rotated logs"}
+	if strings.Join(rows, "|") != strings.Join(want, "|") {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+	if len(tbl.Rows) != 4 {
+		t.Errorf("collapsing changed the stored rows to %d", len(tbl.Rows))
+	}
+}
+
+func TestAutoNumericAlignment(t *testing.T) {
+	tbl := newTestTable("Mixed", "Price")
+	tbl.SetAutoNumericAlignment(true)
+	tbl.AddRow([]string{"12", "$1,200.50"})
+	tbl.AddRow([]string{"n/a", "7"})
+
+	lines := renderLines(tbl)
+	mixed, price := cells(lines[5])[0], cells(lines[5])[1]
+	if !strings.HasPrefix(mixed, " n/a ") {
+		t.Errorf("mixed column cell %q isn't left-aligned", mixed)
+	}
+	if !strings.HasSuffix(price, " 7 ") || !strings.HasPrefix(price, "  ") {
+		t.Errorf("numeric column cell %q isn't right-aligned", price)
+	}
+}
+
+func TestRenderLimitsRejectLongOutput(t *testing.T) {
+	tbl := newTestTable("N")
+	for i := range 100 {
+		tbl.AddRow([]string{strconv.Itoa(i)})
+	}
+	tbl.SetRenderLimits(50, 0)
+
+	out, err := tbl.RenderErr()
+	if !errors.Is(err, ErrRenderLimitExceeded) {
+		t.Fatalf("RenderErr error = %v, want ErrRenderLimitExceeded", err)
+	}
+	if out != "" {
+		t.Errorf("RenderErr returned %d bytes of output along with the error", len(out))
+	}
+
+	tbl.SetRenderLimits(0, 0)
+	if _, err := tbl.RenderErr(); err != nil {
+		t.Errorf("RenderErr without limits = %v", err)
+	}
+}
+
+func TestZebraShadesAlternateRows(t *testing.T) {
+	const odd, even = "\x1b[48;5;236m", "\x1b[48;5;238m"
+	tbl := newTestTable("Host", "Load")
+	tbl.SetANSISupport(true)
+	tbl.SetDimBorder(false)
+	tbl.SetZebra(odd, even)
+	tbl.AddRow([]string{"alpha", "1"})
+	tbl.AddRow([]string{"beta", "2"})
+
+	lines := renderLines(tbl)
+	for _, tc := range []struct {
+		line      string
+		bg, other string
+	}{{lines[3], odd, even}, {lines[5], even, odd}} {
+		if strings.Contains(tc.line, tc.other) {
+			t.Errorf("row %q has the other row's background", tc.line)
+		}
+		// The background opens before the leading padding space
+		for _, cell := range cells(tc.line) {
+			if !strings.HasPrefix(cell, tc.bg+" ") || !strings.HasSuffix(cell, " \x1b[0m") {
+				t.Errorf("cell %q isn't shaded across its padding with %q", cell, tc.bg)
+			}
+		}
+	}
+
+	tbl.SetANSISupport(false)
+	if strings.Contains(tbl.Render(), "\x1b[") {
+		t.Error("zebra shading applied without ANSI support")
+	}
+}
+
+func TestGraphvizRecordEscapesSpecialCharacters(t *testing.T) {
+	tbl := newTestTable("Key", "Value")
+	tbl.AddRow([]string{"{a}", "x|y"})
+	tbl.AddRow([]string{"plain", "<port>"})
+
+	want := `{ { Key | Value } | { \{a\} | x\|y } | { plain | \<port\> } }`
+	if got := tbl.RenderGraphvizRecord(); got != want {
+		t.Errorf("RenderGraphvizRecord() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCellFormatterColorsMatchingCells(t *testing.T) {
+	const red = "\x1b[31m"
+	tbl := newTestTable("Check", "Status")
+	tbl.SetANSISupport(true)
+	tbl.SetDimBorder(false)
+	tbl.SetHeaderHighlighting(false)
+	tbl.AddRow([]string{"lint", "PASS"})
+	tbl.AddRow([]string{"unit", "FAIL"})
+	tbl.SetCellFormatter(func(row, col int, value string) string {
+		if value == "FAIL" {
+			return red + value + "\x1b[0m"
+		}
+		return value
+	})
+
+	lines := renderLines(tbl)
+	for i, line := range lines {
+		if colored := strings.Contains(line, red); colored != (i == 5) {
+			t.Errorf("line %d %q: colored = %v", i, line, colored)
+		}
+	}
+	if got := cells(lines[5])[1]; got != " "+red+"FAIL\x1b[0m   " {
+		t.Errorf("FAIL cell = %q, want it colored and padded by its visible width", got)
+	}
+
+	tbl.SetANSISupport(false)
+	if strings.Contains(tbl.Render(), red) {
+		t.Error("formatter ran without ANSI support")
+	}
+}
+
+func TestPaddingZeroHugsContent(t *testing.T) {
+	tbl := newTestTable("Host", "Load")
+	tbl.SetPadding(0)
+	tbl.AddRow([]string{"alpha", "1"})
+
+	lines := renderLines(tbl)
+	if got := cells(lines[3]); got[0] != "alpha" || got[1] != "1   " {
+		t.Errorf("cells = %q, want no padding around the content", got)
+	}
+	for _, line := range lines {
+		if w := DisplayWidth(line); w != DisplayWidth(lines[0]) {
+			t.Errorf("line %q is %d wide, top border %d", line, w, DisplayWidth(lines[0]))
+		}
+	}
+	if lines[0] != "┌─────┬────┐" {
+		t.Errorf("top border = %q, want it to span the unpadded columns", lines[0])
+	}
+
+	tbl.SetPadding(-1)
+	if got := renderLines(tbl)[3]; got != lines[3] {
+		t.Errorf("negative padding changed the row to %q", got)
+	}
+}
+
+func TestColumnWidthPercentsSplitConsoleWidth(t *testing.T) {
+	tbl := newTestTable("A", "B", "C")
+	tbl.AddRow([]string{"x", "y", "z"})
+	if err := tbl.SetColumnWidthPercents([]float64{50, 25, 25}); err != nil {
+		t.Fatal(err)
+	}
+
+	tbl.Render()
+	// 80 columns less 4 borders and 3 padded cells leave 70 for content
+	want := []int{35, 17, 17}
+	if got := tbl.GetColumnWidths(); !slices.Equal(got, want) {
+		t.Errorf("column widths = %v, want %v", got, want)
+	}
+	if err := tbl.SetColumnWidthPercents([]float64{60, 30, 20}); err == nil {
+		t.Error("SetColumnWidthPercents accepted percentages summing to 110")
+	}
+}
+
+func TestEmptyMessageFillsHeadersOnlyTable(t *testing.T) {
+	tbl := newTestTable("Name", "Value")
+	tbl.SetEmptyMessage("No data")
+
+	lines := renderLines(tbl)
+	want := []string{
+		"┌──────┬───────┐",
+		"│ Name │ Value │",
+		"├──────┴───────┤",
+		"│   No data    │",
+		"└──────────────┘",
+	}
+	if !slices.Equal(lines, want) {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	tbl.AddRow([]string{"alpha", "1"})
+	if strings.Contains(tbl.Render(), "No data") {
+		t.Error("empty message rendered for a table with rows")
+	}
+}
+
+func TestTabsExpandToColumnStops(t *testing.T) {
+	tbl := newTestTable("K\tV", "Note")
+	tbl.AddRow([]string{"a\tb", "x"})
+	tbl.AddRow([]string{"abcdef\tg", "y"})
+
+	lines := renderLines(tbl)
+	for _, line := range lines {
+		if strings.Contains(line, "\t") {
+			t.Errorf("line %q still holds a tab", line)
+		}
+		if DisplayWidth(line) != DisplayWidth(lines[0]) {
+			t.Errorf("line %q doesn't line up with the top border %q", line, lines[0])
+		}
+	}
+	// With the default tab width of 8 the second field starts at column 8
+	for i, want := range map[int]string{1: " K       V ", 3: " a       b ", 5: " abcdef  g "} {
+		if got := cells(lines[i])[0]; got != want {
+			t.Errorf("line %d cell = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestTabsExpandFromWrappedLineStart(t *testing.T) {
+	tbl := newTestTable("Text")
+	tbl.SetMaxWidth(0, 10)
+	tbl.SetTabWidth(4)
+	tbl.SetWrapMode(WrapChar)
+	tbl.AddRow([]string{"abcdefghijk\tx"})
+
+	lines := renderLines(tbl)
+	if got := cells(lines[4])[0]; got != " k   x      " {
+		t.Errorf("wrapped line = %q, want the tab to reach the stop after \"k\"", got)
+	}
+}
+
+func TestHashColumnMatchesIdenticalSources(t *testing.T) {
+	tbl := newTestTable("Package", "Version", "Scanned")
+	tbl.AddRow([]string{"openssl", "3.0.2", "mon"})
+	tbl.AddHashColumn("Hash", []int{0, 1})
+	row := []string{"openssl", "3.0.2", "tue"}
+	tbl.AddRow(row)
+	row[1] = "9.9.9" // The table keeps its own copy
+	tbl.AddRow([]string{"zlib", "1.3", "tue"})
+
+	hash := func(ri int) string { return tbl.Rows[ri][3] }
+	if len(hash(0)) != 8 {
+		t.Fatalf("hash cell = %q, want 8 hex digits", hash(0))
+	}
+	if hash(0) != hash(1) {
+		t.Errorf("identical sources hash to %q and %q", hash(0), hash(1))
+	}
+	if hash(0) == hash(2) {
+		t.Errorf("different sources both hash to %q", hash(0))
+	}
+	if tbl.Rows[1][1] != "3.0.2" {
+		t.Errorf("editing the added slice changed the stored row to %q", tbl.Rows[1])
+	}
+}
+
+func TestEmbeddedNewlineBreaksWideCell(t *testing.T) {
+	tbl := newTestTable("Notes")
+	tbl.SetColumnWidth(0, 40)
+	tbl.AddRow([]string{"line1\nline2"})
+
+	lines := renderLines(tbl)
+	if len(lines) != 6 {
+		t.Fatalf("got %d lines, want the row on exactly two:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	for i, want := range []string{"line1", "line2"} {
+		if got := strings.TrimSpace(cells(lines[3+i])[0]); got != want {
+			t.Errorf("row line %d = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestEmbeddedNewlineKeepsColorOnEachLine(t *testing.T) {
+	tbl := newTestTable("Notes")
+	tbl.SetANSISupport(true)
+	tbl.SetDimBorder(false)
+	tbl.SetHeaderHighlighting(false)
+	tbl.AddRow([]string{"\x1b[31mline1\nline2\x1b[0m"})
+
+	lines := renderLines(tbl)
+	for i, want := range []string{"line1", "line2"} {
+		if got := cells(lines[3+i])[0]; got != " \x1b[31m"+want+"\x1b[0m " {
+			t.Errorf("row line %d = %q, want it colored and reset on its own", i, got)
+		}
+	}
+}
+
+func TestNewTableWithAppliesOptions(t *testing.T) {
+	tbl := NewTableWith([]string{"Name", "Size"},
+		WithANSISupport(true),
+		WithBorderless(true),
+		WithConsoleWidth(60),
+		WithFillWidth(true),
+		WithDimBorder(false),
+		WithAlignment(1, "right"),
+	)
+
+	if !tbl.borderless || tbl.consoleWidth != 60 || !tbl.fillWidth {
+		t.Errorf("borderless = %v, consoleWidth = %d, fillWidth = %v, want true, 60, true",
+			tbl.borderless, tbl.consoleWidth, tbl.fillWidth)
+	}
+	// WithDimBorder comes after WithANSISupport, which also enables dimming
+	if !tbl.supportANSI || tbl.dimBorder {
+		t.Errorf("supportANSI = %v, dimBorder = %v, want true, false", tbl.supportANSI, tbl.dimBorder)
+	}
+	if tbl.alignments[0] != "left" || tbl.alignments[1] != "right" {
+		t.Errorf("alignments = %q, want [left right]", tbl.alignments)
+	}
+}
+
+func TestSeparatorBeforeTotalRow(t *testing.T) {
+	tbl := newTestTable("Item", "Cost")
+	tbl.AddRows([][]string{{"a", "1"}, {"b", "2"}, {"Total", "3"}})
+	tbl.SetSeparatorBefore(2, "double")
+
+	lines := renderLines(tbl)
+	if lines[6] != "╞═══════╪══════╡" {
+		t.Errorf("separator above the total = %q, want a double rule", lines[6])
+	}
+	if lines[4] != lines[2] || lines[4] != "├───────┼──────┤" {
+		t.Errorf("other separators = %q, %q, want the default middle border", lines[2], lines[4])
+	}
+	if strings.TrimSpace(cells(lines[7])[0]) != "Total" {
+		t.Errorf("line after the double rule = %q, want the total row", lines[7])
+	}
+}
+
+func TestFromStructsReadsFieldsAndTags(t *testing.T) {
+	type image struct {
+		Name  string
+		Size  int    `table:"Size (MB)"`
+		notes string // Unexported, so skipped
+	}
+	tbl, err := FromStructs([]image{{Name: "nginx", Size: 42}, {Name: "redis", Size: 30}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"Name", "Size (MB)"}; !slices.Equal(tbl.Headers, want) {
+		t.Errorf("headers = %q, want %q", tbl.Headers, want)
+	}
+	want := [][]string{{"nginx", "42"}, {"redis", "30"}}
+	if !slices.EqualFunc(tbl.Rows, want, slices.Equal[[]string]) {
+		t.Errorf("rows = %q, want %q", tbl.Rows, want)
+	}
+
+	for _, v := range []any{image{}, []int{1}, nil} {
+		if _, err := FromStructs(v); err == nil {
+			t.Errorf("FromStructs(%T) returned no error", v)
+		}
+	}
+}
+
+func TestWideCharacterTitleCentersByDisplayWidth(t *testing.T) {
+	tbl := newTestTable("Names", "Values")
+	tbl.AddRow([]string{"alpha", "1"})
+	tbl.SetTitle("報告x") // 5 columns wide
+
+	lines := renderLines(tbl)
+	title, border := lines[0], lines[1]
+	if DisplayWidth(border) != 18 {
+		t.Fatalf("top border %q is %d wide, want 18", border, DisplayWidth(border))
+	}
+	for _, line := range lines[2:] {
+		if DisplayWidth(line) != DisplayWidth(border) {
+			t.Errorf("line %q doesn't line up with the top border", line)
+		}
+	}
+	// 13 columns are left over: the odd one goes to the right
+	left := len(title) - len(strings.TrimLeft(title, " "))
+	if left != 6 || strings.TrimSpace(title) != "報告x" {
+		t.Errorf("title = %q, want \"報告x\" after 6 spaces", title)
+	}
+}
+
+func TestFromRecordsPadsShortRecords(t *testing.T) {
+	tbl := FromRecords([]string{"A", "B", "C"}, [][]string{{"1", "2", "3"}, {"4"}})
+
+	want := [][]string{{"1", "2", "3"}, {"4", "", ""}}
+	if !slices.EqualFunc(tbl.Rows, want, slices.Equal[[]string]) {
+		t.Errorf("rows = %q, want %q", tbl.Rows, want)
+	}
+}
+
+func TestFromCSVKeepsQuotedCommas(t *testing.T) {
+	in := "Name,Tags\nnginx,\"web,proxy\"\nredis\npostgres,db,extra\n"
+	tbl, err := FromCSV(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"Name", "Tags"}; !slices.Equal(tbl.Headers, want) {
+		t.Errorf("headers = %q, want %q", tbl.Headers, want)
+	}
+	want := [][]string{{"nginx", "web,proxy"}, {"redis", ""}, {"postgres", "db"}}
+	if !slices.EqualFunc(tbl.Rows, want, slices.Equal[[]string]) {
+		t.Errorf("rows = %q, want %q", tbl.Rows, want)
+	}
+
+	var parseErr *csv.ParseError
+	if _, err := FromCSV(strings.NewReader("a,b\n\"unterminated\n")); !errors.As(err, &parseErr) {
+		t.Errorf("FromCSV error for malformed CSV = %v, want the reader's *csv.ParseError", err)
+	}
+	if _, err := FromCSV(strings.NewReader("")); err == nil {
+		t.Error("FromCSV returned no error for input without a header record")
+	}
+}
+
+func TestHeaderSeparatorBarFillsHalfSegment(t *testing.T) {
+	tbl := newTestTable("Disk", "Mount")
+	tbl.AddRow([]string{"sda", "/"})
+	tbl.SetHeaderSeparatorBars(map[int]float64{0: 0.5})
+
+	sep := renderLines(tbl)[2]
+	segments := strings.Split(strings.Trim(sep, "├┤"), "┼")
+	// " Disk " is six columns wide, the bar fills three of them
+	if segments[0] != "███───" {
+		t.Errorf("column 0 segment = %q, want half filled", segments[0])
+	}
+	if segments[1] != "───────" {
+		t.Errorf("column 1 segment = %q, want a plain rule", segments[1])
+	}
+}
+
+func TestHyperlinkRendersLabelWithoutANSI(t *testing.T) {
+	tbl := newTestTable("Advisory")
+	tbl.AddRow([]string{Hyperlink("https://example.com/CVE-1", "CVE-1")})
+	tbl.AddRow([]string{"\x1b]8;;https://example.com/CVE-2\x07CVE-2\x1b]8;;\x07"})
+
+	out := tbl.Render()
+	if strings.Contains(out, "example.com") || strings.Contains(out, "\x1b") {
+		t.Errorf("output leaks the URL or escape bytes:\n%q", out)
+	}
+	lines := renderLines(tbl)
+	for i, want := range map[int]string{3: " CVE-1    ", 5: " CVE-2    "} {
+		if got := cells(lines[i])[0]; got != want {
+			t.Errorf("line %d cell = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestAccessorsReportSizeAndCopyWidths(t *testing.T) {
+	tbl := newTestTable("ID", "Name", "Status")
+	tbl.AddRows([][]string{{"1", "alpha", "up"}, {"2", "beta", "down"}})
+
+	if tbl.RowCount() != 2 || tbl.ColumnCount() != 3 {
+		t.Errorf("RowCount() = %d, ColumnCount() = %d, want 2 and 3", tbl.RowCount(), tbl.ColumnCount())
+	}
+	// Measured before any render
+	widths := tbl.GetColumnWidths()
+	if want := []int{2, 5, 6}; !slices.Equal(widths, want) {
+		t.Fatalf("GetColumnWidths() = %v, want %v", widths, want)
+	}
+	widths[1] = 50
+	if got := tbl.GetColumnWidths(); got[1] != 5 {
+		t.Errorf("changing the returned slice changed the widths to %v", got)
+	}
+	if got := cells(renderLines(tbl)[3])[1]; got != " alpha " {
+		t.Errorf("cell = %q, want the width unaffected by the caller's edit", got)
+	}
+}
+
+func TestAddRowsPadsShortRows(t *testing.T) {
+	tbl := newTestTable("A", "B", "C")
+	if got := tbl.AddRows([][]string{{"1", "2", "3"}, {"4"}, {}, {"5", "6"}}); got != tbl {
+		t.Error("AddRows didn't return the table for chaining")
+	}
+
+	if len(tbl.Rows) != 4 {
+		t.Fatalf("got %d rows, want 4", len(tbl.Rows))
+	}
+	for i, row := range tbl.Rows {
+		if len(row) != len(tbl.Headers) {
+			t.Errorf("row %d = %q, want %d cells", i, row, len(tbl.Headers))
+		}
+	}
+	if want := []string{"5", "6", ""}; !slices.Equal(tbl.Rows[3], want) {
+		t.Errorf("row 3 = %q, want %q", tbl.Rows[3], want)
+	}
+}
+
+func TestFixedWidthFieldsAtExactOffsets(t *testing.T) {
+	tbl := newTestTable("ID", "Name", "Amount")
+	tbl.AddRow([]string{"7", "\x1b[31mcontoso\x1b[0m", "1200"})
+	tbl.AddRow([]string{"12345", "ab", ""})
+
+	widths := []int{4, 6, 5}
+	lines := strings.Split(strings.TrimSuffix(tbl.RenderFixedWidth(widths, '.'), "\n"), "\n")
+	want := []string{"7...contos1200.", "1234ab........."}
+	if !slices.Equal(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+	for _, line := range lines {
+		if len(line) != 4+6+5 {
+			t.Errorf("line %q is %d bytes, want 15", line, len(line))
+		}
+	}
+}
+
+func TestSetHeadersRenamesColumns(t *testing.T) {
+	tbl := newTestTable("id", "name")
+	tbl.AddRow([]string{"1", "alpha"})
+	tbl.Render()
+	if err := tbl.SetHeaders([]string{"Identifier", "Name"}); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := renderLines(tbl)
+	if got := cells(lines[1]); strings.TrimSpace(got[0]) != "Identifier" || strings.TrimSpace(got[1]) != "Name" {
+		t.Errorf("header = %q, want the new names", lines[1])
+	}
+	if w := DisplayWidth(cells(lines[3])[0]); w != len(" Identifier ") {
+		t.Errorf("column 0 is %d wide, want it resized to the new header", w)
+	}
+	if want := []string{"1", "alpha"}; !slices.Equal(tbl.Rows[0], want) {
+		t.Errorf("row = %q, want %q", tbl.Rows[0], want)
+	}
+	if err := tbl.SetHeaders([]string{"Only"}); err == nil {
+		t.Error("SetHeaders accepted a different column count")
+	}
+}
+
+func TestCollatorSortsAccentedWords(t *testing.T) {
+	words := [][]string{{"zèbre"}, {"étoile"}, {"eau"}, {"fleur"}}
+	column := func(tbl *Table) []string {
+		var got []string
+		for _, row := range tbl.Rows {
+			got = append(got, row[0])
+		}
+		return got
+	}
+
+	naive := newTestTable("Mot")
+	naive.AddRows(words)
+	naive.SortByColumn(0, true)
+	// Byte order puts "é" (0xC3 0xA9) after every ASCII letter
+	if want := []string{"eau", "fleur", "zèbre", "étoile"}; !slices.Equal(column(naive), want) {
+		t.Errorf("byte order = %q, want %q", column(naive), want)
+	}
+
+	tbl := newTestTable("Mot")
+	tbl.AddRows(words)
+	tbl.SetCollator(0, collate.New(language.French))
+	tbl.SortByColumn(0, true)
+	if want := []string{"eau", "étoile", "fleur", "zèbre"}; !slices.Equal(column(tbl), want) {
+		t.Errorf("collated order = %q, want %q", column(tbl), want)
+	}
+}
+
+func TestColorLegendListsOnlyUsedColors(t *testing.T) {
+	const red, green, yellow = "\x1b[31m", "\x1b[32m", "\x1b[33m"
+	tbl := newTestTable("Check", "Status")
+	tbl.SetANSISupport(true)
+	tbl.AddRow([]string{"lint", green + "ok" + "\x1b[0m"})
+	tbl.AddRow([]string{"unit", red + "failed" + "\x1b[0m"})
+	tbl.SetColorLegend(map[string]string{red: "failed", green: "ok", yellow: "skipped"})
+
+	lines := renderLines(tbl)
+	legend := lines[len(lines)-2:]
+	want := []string{red + "■\x1b[0m failed", green + "■\x1b[0m ok"}
+	if !slices.Equal(legend, want) {
+		t.Errorf("legend = %q, want %q", legend, want)
+	}
+	if strings.Contains(tbl.Render(), "skipped") {
+		t.Error("legend lists a color no cell uses")
+	}
+}
+
+func TestWrapModesOnPath(t *testing.T) {
+	const path = "/usr/local/lib/python3 site"
+	for _, tc := range []struct {
+		name string
+		mode WrapMode
+		want []string
+	}{
+		{"auto", WrapAuto, []string{"/usr/local", "/lib", "/python3", "site"}},
+		{"word", WrapWord, []string{"/usr/local/l", "ib/python3", "site"}},
+		{"char", WrapChar, []string{"/usr/local/l", "ib/python3 s", "ite"}},
+		{"none", WrapNone, []string{path}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tbl := newTestTable("Path")
+			tbl.SetMaxWidth(0, 12)
+			tbl.SetWrapMode(tc.mode)
+			tbl.AddRow([]string{path})
+
+			lines := renderLines(tbl)
+			var got []string
+			for _, line := range lines[3 : len(lines)-1] {
+				got = append(got, strings.TrimSpace(cells(line)[0]))
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("lines = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHyphenationMarksBrokenWord(t *testing.T) {
+	const token = "abcdefghijklmnopqrstuvwxyz0123" // 30 characters
+	tbl := newTestTable("Token")
+	tbl.SetMaxWidth(0, 10)
+	tbl.SetHyphenation(true)
+	tbl.AddRow([]string{token})
+
+	lines := renderLines(tbl)
+	var rendered []string
+	for _, line := range lines[3 : len(lines)-1] {
+		rendered = append(rendered, strings.TrimSpace(cells(line)[0]))
+	}
+	splits := map[string][]string{
+		"rendered cell":     rendered,
+		"splitByWords":      tbl.splitByWords(token, 10),
+		"smartSplitByWords": tbl.smartSplitByWords(token, 10),
+	}
+	for name, parts := range splits {
+		if len(parts) < 2 {
+			t.Fatalf("%s: got %q, want the token broken", name, parts)
+		}
+		joined := ""
+		for i, part := range parts {
+			if last := i == len(parts)-1; last == strings.HasSuffix(part, "-") {
+				t.Errorf("%s: fragment %d %q, last = %v", name, i, part, last)
+			}
+			if DisplayWidth(part) > 10 {
+				t.Errorf("%s: fragment %q is wider than 10", name, part)
+			}
+			joined += strings.TrimSuffix(part, "-")
+		}
+		if joined != token {
+			t.Errorf("%s: fragments %q don't join back to the token", name, parts)
+		}
+	}
+}
+
+func TestFromCSVRoundTripsThroughRender(t *testing.T) {
+	in := "Name,Notes,Extra\nnginx,\"line one\nline two\",x,dropped\nredis\n"
+	tbl, err := FromCSV(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{{"nginx", "line one\nline two", "x"}, {"redis", "", ""}}
+	if !slices.EqualFunc(tbl.Rows, want, slices.Equal[[]string]) {
+		t.Errorf("rows = %q, want %q", tbl.Rows, want)
+	}
+	again, err := FromCSV(strings.NewReader(tbl.RenderCSV()))
+	if err != nil || !slices.EqualFunc(again.Rows, want, slices.Equal[[]string]) {
+		t.Errorf("RenderCSV didn't read back the same rows: %q, %v", again.Rows, err)
+	}
+
+	tbl.SetANSISupport(false)
+	tbl.SetConsoleWidth(80)
+	tbl.SetBorderChars(UnicodeBorderChars)
+	lines := renderLines(tbl)
+	if got := strings.TrimSpace(cells(lines[4])[1]); got != "line two" {
+		t.Errorf("second line of the notes cell = %q, want \"line two\"", got)
+	}
+}
+
+func TestFlexColumnAbsorbsExtraWidth(t *testing.T) {
+	tbl := newTestTable("ID", "Description", "Owner")
+	tbl.SetANSISupport(true) // Fill width only applies to terminal output
+	tbl.SetFillWidth(true)
+	tbl.AddRow([]string{"1", "disk full", "ops"})
+
+	natural := tbl.GetColumnWidths()
+	tbl.SetFlexColumns([]int{1})
+	got := tbl.GetColumnWidths()
+
+	if got[0] != 2 || got[2] != 5 {
+		t.Errorf("widths = %v, want the ID and Owner columns left at 2 and 5", got)
+	}
+	// 80 columns less 4 borders and the padding of 3 cells
+	if sum := got[0] + got[1] + got[2]; sum != 80-4-6 {
+		t.Errorf("widths %v sum to %d, want the table to fill 80 columns", got, sum)
+	}
+	if natural[0] <= 2 || natural[2] <= 5 {
+		t.Errorf("without flex columns widths = %v, want every column to grow", natural)
+	}
+}
+
+func TestDottedIdentifierWrapsAtDots(t *testing.T) {
+	const class = "com.example.foo.bar.baz.Service"
+	tbl := newTestTable("Class")
+	tbl.SetMaxWidth(0, 16)
+	tbl.AddRow([]string{class})
+
+	lines := renderLines(tbl)
+	var got []string
+	for _, line := range lines[3 : len(lines)-1] {
+		got = append(got, strings.TrimSpace(cells(line)[0]))
+	}
+	// Each break falls at a dot, which starts the following line
+	if want := []string{"com.example.foo", ".bar.baz.Service"}; !slices.Equal(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestWrapDelimitersBreakAtSemicolons(t *testing.T) {
+	tbl := newTestTable("Hosts")
+	tbl.SetMaxWidth(0, 12)
+	tbl.SetWrapDelimiters([]string{";"})
+	tbl.AddRow([]string{"alpha;beta;gamma;delta;epsilon"})
+
+	lines := renderLines(tbl)
+	var got []string
+	for _, line := range lines[3 : len(lines)-1] {
+		got = append(got, strings.TrimSpace(cells(line)[0]))
+	}
+	// Whole items per line; like commas, the delimiter is dropped at a break
+	if want := []string{"alpha; beta", "gamma; delta", "epsilon"}; !slices.Equal(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestHiddenHeaderStartsBoxAtFirstRow(t *testing.T) {
+	tbl := newTestTable("Name", "Value")
+	tbl.SetShowHeader(false)
+	tbl.AddRow([]string{"a", "1"})
+	tbl.AddRow([]string{"b", "2"})
+
+	lines := renderLines(tbl)
+	out := strings.Join(lines, "\n")
+	if strings.Contains(out, "Name") || strings.Contains(out, "Value") {
+		t.Errorf("header text rendered:\n%s", out)
+	}
+	// The header strings still size the columns
+	want := []string{
+		"┌──────┬───────┐",
+		"│ a    │ 1     │",
+		"├──────┼───────┤",
+		"│ b    │ 2     │",
+		"└──────┴───────┘",
+	}
+	if !slices.Equal(lines, want) {
+		t.Errorf("got\n%s\nwant\n%s", out, strings.Join(want, "\n"))
+	}
+}
+
+func TestRenderVerticalPairsFieldsWithValues(t *testing.T) {
+	tbl := newTestTable("Name", "Image", "Tag", "Size")
+	tbl.AddRow([]string{"web", "nginx", "1.25", "42"})
+
+	var pairs []string
+	for _, line := range strings.Split(tbl.RenderVertical(), "\n") {
+		if c := cells(line); c != nil {
+			pairs = append(pairs, strings.TrimSpace(c[0])+"="+strings.TrimSpace(c[1]))
+		}
+	}
+	want := []string{"Field=Value", "Name=web", "Image=nginx", "Tag=1.25", "Size=42"}
+	if !slices.Equal(pairs, want) {
+		t.Errorf("field/value lines = %q, want %q", pairs, want)
+	}
+}
+
+func TestDefaultMaxColumnWidthRaisesCap(t *testing.T) {
+	value := strings.Repeat("x", 70)
+	tbl := newTestTable("Value")
+	tbl.AddRow([]string{value})
+	if got := tbl.GetColumnWidths()[0]; got != 50 {
+		t.Fatalf("default cap gives width %d, want 50", got)
+	}
+
+	tbl.SetDefaultMaxColumnWidth(100)
+	lines := renderLines(tbl)
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want the value on one line:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	if got := strings.TrimSpace(cells(lines[3])[0]); got != value {
+		t.Errorf("cell = %q, want the 70 character value whole", got)
+	}
+}
+
+func TestColumnFormatterGroupsThousands(t *testing.T) {
+	tbl := newTestTable("Item", "Bytes")
+	tbl.SetAlignment(1, "right")
+	tbl.AddRow([]string{"image", "1234567"})
+	tbl.AddRow([]string{"layer", "999"})
+	tbl.SetColumnFormatter(1, GroupThousands)
+
+	lines := renderLines(tbl)
+	if got := cells(lines[3])[1]; got != " 1,234,567 " {
+		t.Errorf("formatted cell = %q, want \" 1,234,567 \"", got)
+	}
+	if got := cells(lines[5])[1]; got != "       999 " {
+		t.Errorf("short cell = %q, want it right-aligned to the formatted width", got)
+	}
+	if tbl.Rows[0][1] != "1234567" {
+		t.Errorf("stored value = %q, want it unformatted", tbl.Rows[0][1])
+	}
+}
+
+func TestDecimalAlignmentLinesUpPoints(t *testing.T) {
+	tbl := newTestTable("Amount")
+	tbl.SetDecimalAlignment(0)
+	tbl.AddRows([][]string{{"1.5"}, {"22.25"}, {"100"}, {"12.5%"}, {"n/a"}})
+
+	lines := renderLines(tbl)
+	row := func(ri int) string { return cells(lines[3+2*ri])[0] }
+	point := strings.Index(row(1), ".")
+	for _, ri := range []int{0, 3} {
+		if got := strings.Index(row(ri), "."); got != point {
+			t.Errorf("decimal point of %q is at %d, want %d as in %q", row(ri), got, point, row(1))
+		}
+	}
+	// An integer ends where the others' integer parts do
+	if got := len(strings.TrimRight(row(2), " ")); got != point {
+		t.Errorf("integer %q ends at %d, want %d", row(2), got, point)
+	}
+	if !strings.HasSuffix(row(4), "n/a ") {
+		t.Errorf("non-numeric cell %q isn't right-aligned", row(4))
+	}
+}
+
+func TestGroupThousands(t *testing.T) {
+	for in, want := range map[string]string{
+		"1234567":     "1,234,567",
+		"-1234.50":    "-1,234.50",
+		"$1000":       "$1,000",
+		"12345%":      "12,345%",
+		"999":         "999",
+		"1,234":       "1,234",
+		"n/a":         "n/a",
+		"12345.67891": "12,345.67891",
+	} {
+		if got := GroupThousands(in); got != want {
+			t.Errorf("GroupThousands(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestBarColumnScalesToColumnWidth(t *testing.T) {
+	tbl := newTestTable("Host", "Load")
+	tbl.SetColumnWidth(1, 24)
+	tbl.SetBarColumn(1, 100)
+	tbl.AddRows([][]string{{"a", "25"}, {"b", "50"}, {"c", "100"}})
+
+	// 24 columns less the widest label and the space before it leave 20
+	// for the bar
+	lines := renderLines(tbl)
+	for i, want := range []int{5, 10, 20} {
+		cell := cells(lines[3+2*i])[1]
+		if got := strings.Count(cell, "#"); got != want {
+			t.Errorf("cell %q has a bar of %d, want %d", cell, got, want)
+		}
+	}
+
+	tbl.SetANSISupport(true)
+	if got := strings.Count(tbl.Render(), "█"); got != 5+10+20 {
+		t.Errorf("ANSI output has %d block characters, want 35", got)
+	}
+}
+
+func TestHighlightCellBoldsOnlyThatCell(t *testing.T) {
+	tbl := newTestTable("Host", "Load")
+	tbl.SetANSISupport(true)
+	tbl.SetDimBorder(false)
+	tbl.SetHeaderHighlighting(false)
+	tbl.AddRows([][]string{{"alpha", "25"}, {"beta", "50"}})
+	plain := StripANSI(tbl.Render())
+	tbl.HighlightCell(1, 0)
+
+	out := tbl.Render()
+	if n := strings.Count(out, BoldStyleStart); n != 1 {
+		t.Fatalf("output has %d bold sequences, want 1:\n%q", n, out)
+	}
+	if got := cells(renderLines(tbl)[5])[0]; got != " "+BoldStyleStart+"beta"+BoldStyleEnd+"  " {
+		t.Errorf("highlighted cell = %q, want bold \"beta\"", got)
+	}
+	if StripANSI(out) != plain {
+		t.Errorf("highlighting changed the layout:\n%s", StripANSI(out))
+	}
+}
+
+func TestRowColorTakesPrecedenceOverColumnColor(t *testing.T) {
+	const red, gray = "\x1b[31m", "\x1b[90m"
+	tbl := newTestTable("Host", "Load")
+	tbl.SetANSISupport(true)
+	tbl.SetDimBorder(false)
+	tbl.SetHeaderHighlighting(false)
+	tbl.AddRows([][]string{{"alpha", "25"}, {"beta", "50"}})
+	plain := StripANSI(tbl.Render())
+	tbl.SetRowColor(0, red)
+	tbl.SetColumnColor(1, gray)
+
+	lines := renderLines(tbl)
+	want := map[[2]int]string{
+		{3, 0}: " " + red + "alpha\x1b[0m ",
+		{3, 1}: " " + red + "25\x1b[0m   ", // The row color wins
+		{5, 0}: " beta  ",
+		{5, 1}: " " + gray + "50\x1b[0m   ",
+	}
+	for pos, w := range want {
+		if got := cells(lines[pos[0]])[pos[1]]; got != w {
+			t.Errorf("line %d cell %d = %q, want %q", pos[0], pos[1], got, w)
+		}
+	}
+	if strings.Contains(lines[1], "\x1b[") {
+		t.Errorf("header %q is tinted", lines[1])
+	}
+	if got := StripANSI(tbl.Render()); got != plain {
+		t.Errorf("colors changed the layout:\n%s", got)
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	tbl := newTestTable("Host", "Load")
+	tbl.AddRows([][]string{{"alpha", "25"}, {"beta", "50"}})
+	tbl.SetAlignment(1, "right")
+	tbl.SetMaxWidth(0, 10)
+	tbl.AddDescription(0, "primary")
+	g := NewGroup()
+	g.Add(tbl)
+	before := tbl.Render()
+
+	c := tbl.Clone()
+	c.Rows[0][0] = "gamma"
+	c.Headers[1] = "CPU"
+	c.Descriptions[0][0] = "replica"
+	c.SetAlignment(1, "left")
+	c.SetMaxWidth(0, 3)
+	c.AddRow([]string{"delta", "75"})
+
+	if got := tbl.Render(); got != before {
+		t.Errorf("changing the clone changed the original:\n%s", got)
+	}
+	if tbl.Rows[0][0] != "alpha" || tbl.Headers[1] != "Load" || tbl.Descriptions[0][0] != "primary" {
+		t.Errorf("original fields changed: %q %q %q", tbl.Rows[0], tbl.Headers, tbl.Descriptions[0])
+	}
+	if c.group != nil || len(g.GetTables()) != 1 {
+		t.Error("the clone joined the original's group")
+	}
+}
+
+func TestResetKeepsConfigurationDropsRows(t *testing.T) {
+	tbl := newTestTable("Host", "Load")
+	tbl.SetAlignment(1, "right")
+	tbl.SetMaxWidth(0, 8)
+	tbl.AddRows([][]string{{"a-very-long-hostname", "25"}, {"beta", "50"}})
+	tbl.AddDescription(0, "primary")
+	tbl.Render()
+
+	tbl.Reset()
+	tbl.AddRow([]string{"gamma", "7"})
+
+	if len(tbl.Rows) != 1 || len(tbl.Descriptions) != 0 || len(tbl.DescriptionTitles) != 0 {
+		t.Errorf("after Reset rows = %q, descriptions = %q", tbl.Rows, tbl.Descriptions)
+	}
+	lines := renderLines(tbl)
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want only the new row:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	if got := cells(lines[3]); got[0] != " gamma " || got[1] != "    7 " {
+		t.Errorf("row = %q, want it sized afresh and right-aligned", got)
+	}
+	if strings.TrimSpace(cells(lines[1])[0]) != "Host" || tbl.maxWidths[0] != 8 {
+		t.Errorf("Reset lost the headers or max widths: %q, %v", lines[1], tbl.maxWidths)
+	}
+}
+
+func TestColorEnvironmentVariables(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		noColor, force string
+		want           bool
+	}{
+		{"FORCE_COLOR", "", "1", true},
+		{"FORCE_COLOR=true", "", "TRUE", true},
+		{"FORCE_COLOR=0", "", "0", false},
+		{"FORCE_COLOR=false", "", "false", false},
+		{"NO_COLOR", "1", "", false},
+		{"NO_COLOR wins", "1", "1", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tc.noColor)
+			t.Setenv("FORCE_COLOR", tc.force)
+
+			tbl := NewTable([]string{"A"})
+			if tbl.supportANSI != tc.want || tbl.dimBorder != tc.want || tbl.highlightHeaders != tc.want {
+				t.Errorf("supportANSI, dimBorder, highlightHeaders = %v, %v, %v, want all %v",
+					tbl.supportANSI, tbl.dimBorder, tbl.highlightHeaders, tc.want)
+			}
+			tbl.SetANSISupport(!tc.want)
+			if tbl.supportANSI == tc.want {
+				t.Error("SetANSISupport didn't override the environment")
+			}
+		})
+	}
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestRenderToWritesRender(t *testing.T) {
+	tbl := newTestTable("Host", "Load")
+	tbl.AddRow([]string{"alpha", "25"})
+
+	var buf bytes.Buffer
+	if err := tbl.RenderTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != tbl.Render() {
+		t.Errorf("RenderTo wrote\n%s\nRender returns\n%s", buf.String(), tbl.Render())
+	}
+	if err := tbl.RenderTo(failingWriter{}); err == nil || err.Error() != "disk full" {
+		t.Errorf("RenderTo error = %v, want the write error", err)
+	}
+}
+
+func TestNoInnerBordersKeepsOuterFrame(t *testing.T) {
+	tbl := newTestTable("Host", "Load")
+	tbl.AddRows([][]string{{"alpha", "25"}, {"beta", "50"}})
+	framed := renderLines(tbl)
+	tbl.SetInnerBorders(false)
+
+	lines := renderLines(tbl)
+	// Header and two rows between the top and bottom borders
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want no separators:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	for _, line := range lines[1:4] {
+		inner := strings.TrimSuffix(strings.TrimPrefix(line, "│"), "│")
+		if inner == line || strings.ContainsAny(inner, "│┼├┤─") {
+			t.Errorf("line %q lacks the outer frame or has inner borders", line)
+		}
+	}
+	if lines[0] != "┌"+strings.Repeat("─", DisplayWidth(framed[0])-2)+"┐" {
+		t.Errorf("top border = %q, want an unbroken rule as wide as before", lines[0])
+	}
+	if !strings.HasPrefix(lines[4], "└") || strings.Contains(lines[4], "┴") {
+		t.Errorf("bottom border = %q, want an unbroken rule", lines[4])
+	}
+}
+
+func TestRowSeparatorsBetweenPlainRows(t *testing.T) {
+	tbl := newTestTable("Host", "Load")
+	tbl.AddRows([][]string{{"a", "25"}, {"b", "50"}, {"c", "75"}})
+
+	separators := func(lines []string) int {
+		n := 0
+		for i, line := range lines[3 : len(lines)-1] {
+			if strings.HasPrefix(line, "├") {
+				n++
+				if prev := lines[3+i-1]; strings.HasPrefix(prev, "├") {
+					t.Errorf("double separator: %q after %q", line, prev)
+				}
+			}
+		}
+		return n
+	}
+	if n := separators(renderLines(tbl)); n != 2 {
+		t.Errorf("got %d interior separators, want 2", n)
+	}
+	tbl.SetRowSeparators(false)
+	if n := separators(renderLines(tbl)); n != 0 {
+		t.Errorf("got %d interior separators with separators off, want 0", n)
+	}
+
+	// A described row is closed off once, not twice
+	tbl.SetRowSeparators(true)
+	tbl.AddDescription(1, "note")
+	if n := separators(renderLines(tbl)); n != 2 {
+		t.Errorf("got %d interior separators around a description, want 2", n)
+	}
+}
+
+func TestHeaderSeparatorHidden(t *testing.T) {
+	tbl := newTestTable("Host", "Load")
+	tbl.AddRows([][]string{{"a", "25"}, {"b", "50"}})
+	tbl.SetHeaderSeparator(false)
+
+	lines := renderLines(tbl)
+	if lines[0] != "┌──────┬──────┐" {
+		t.Errorf("top border = %q", lines[0])
+	}
+	if strings.TrimSpace(cells(lines[1])[0]) != "Host" || strings.TrimSpace(cells(lines[2])[0]) != "a" {
+		t.Errorf("first row %q doesn't follow the header %q directly", lines[2], lines[1])
+	}
+	// Only the separator below the header goes
+	if lines[3] != "├──────┼──────┤" {
+		t.Errorf("row separator = %q, want it kept", lines[3])
+	}
+}
+
+func TestShrinkProportionalSharesExcess(t *testing.T) {
+	shrunk := func(s ShrinkStrategy, a, b, c int) []int {
+		tbl := newTestTable("A", "B", "C")
+		tbl.SetANSISupport(true) // Shrinking to the console only applies to terminal output
+		tbl.SetConsoleWidth(40)
+		tbl.SetShrinkStrategy(s)
+		tbl.AddRow([]string{strings.Repeat("a ", a), strings.Repeat("b ", b), strings.Repeat("c ", c)})
+		return tbl.GetColumnWidths()
+	}
+
+	// 40 columns less 4 borders and the padding of 3 cells leave 30
+	widths := shrunk(ShrinkProportional, 20, 19, 18)
+	if widths[0]+widths[1]+widths[2] != 30 {
+		t.Fatalf("widths %v don't fill the 30 columns available", widths)
+	}
+	if slices.Max(widths)-slices.Min(widths) > 1 {
+		t.Errorf("widths = %v, want them within one of each other", widths)
+	}
+
+	// Unlike shaving the widest column, wider columns stay wider
+	if got, want := shrunk(ShrinkProportional, 25, 15, 10), []int{13, 10, 7}; !slices.Equal(got, want) {
+		t.Errorf("proportional widths = %v, want %v", got, want)
+	}
+	if got, want := shrunk(ShrinkWidest, 25, 15, 10), []int{10, 10, 10}; !slices.Equal(got, want) {
+		t.Errorf("widest-first widths = %v, want %v", got, want)
+	}
+}
+
+func TestProtectedColumnKeepsNaturalWidth(t *testing.T) {
+	const id = "sha256:abcdef0123456789" // 23 characters
+	tbl := newTestTable("ID", "Name", "Description")
+	tbl.SetANSISupport(true) // Shrinking to the console only applies to terminal output
+	tbl.SetConsoleWidth(40)
+	tbl.AddRow([]string{id, strings.Repeat("b ", 15), strings.Repeat("c ", 10)})
+	c := tbl.Clone()
+	tbl.SetColumnPriority(0, true)
+
+	widths := tbl.GetColumnWidths()
+	if widths[0] != len(id) {
+		t.Errorf("protected column is %d wide, want its natural %d", widths[0], len(id))
+	}
+	if widths[1] >= 29 || widths[2] >= 19 {
+		t.Errorf("widths = %v, want the other columns shrunk", widths)
+	}
+	// Clones don't share the setting
+	if got := c.GetColumnWidths(); got[0] >= len(id) {
+		t.Errorf("clone taken before protecting column 0 has widths %v", got)
+	}
+	c = tbl.Clone()
+	c.SetColumnPriority(0, false)
+	if got := tbl.GetColumnWidths(); got[0] != len(id) {
+		t.Errorf("unprotecting the clone's column shrank the original's to %d", got[0])
+	}
+
+	// Once the others are at their minimum the protected column gives way
+	tbl.SetConsoleWidth(20)
+	if got := tbl.GetColumnWidths(); got[0] >= len(id) || got[1] != 3 || got[2] != 3 {
+		t.Errorf("widths on a 20 column console = %v, want the protected column shrunk last", got)
+	}
+}
+
+func TestExpandProportionalFavorsWideColumns(t *testing.T) {
+	tbl := newTestTable("St", "Description")
+	tbl.SetANSISupport(true) // Fill width only applies to terminal output
+	tbl.SetFillWidth(true)
+	tbl.AddRow([]string{"ok", "the quick brown fox jumps"})
+	natural := []int{2, 25}
+
+	tbl.SetExpandStrategy(ExpandProportional)
+	widths := tbl.GetColumnWidths()
+	narrow, wide := widths[0]-natural[0], widths[1]-natural[1]
+	if wide <= narrow {
+		t.Errorf("widths = %v: the wide column gained %d, the narrow one %d", widths, wide, narrow)
+	}
+	// 80 columns less 3 borders and the padding of 2 cells
+	if widths[0]+widths[1] != 80-3-4 {
+		t.Errorf("widths %v don't fill the console", widths)
+	}
+
+	// Width the capped column can't take goes to the other
+	tbl.SetMaxWidth(1, 30)
+	if got, want := tbl.GetColumnWidths(), []int{43, 30}; !slices.Equal(got, want) {
+		t.Errorf("widths with column 1 capped = %v, want %v", got, want)
+	}
+}
+
+func TestExpandKeepsPinnedWidthOverMaxWidth(t *testing.T) {
+	for _, strategy := range []ExpandStrategy{ExpandEqual, ExpandProportional} {
+		tbl := newTestTable("Name", "Description")
+		tbl.SetANSISupport(true) // Fill width only applies to terminal output
+		tbl.SetFillWidth(true)
+		tbl.AddRow([]string{"ok", "the quick brown fox"})
+		tbl.SetColumnWidth(0, 20)
+		tbl.SetMaxWidth(0, 10)
+		tbl.SetExpandStrategy(strategy)
+
+		// 80 columns less 3 borders and the padding of 2 cells
+		if got, want := tbl.GetColumnWidths(), []int{20, 80 - 3 - 4 - 20}; !slices.Equal(got, want) {
+			t.Errorf("strategy %v: widths = %v, want %v", strategy, got, want)
+		}
+	}
+}
+
+func TestTooLongRowsAreTrimmedOrRejected(t *testing.T) {
+	tbl := newTestTable("A", "B")
+	tbl.AddRow([]string{"1", "2", "3"})
+	if want := []string{"1", "2"}; !slices.Equal(tbl.Rows[0], want) {
+		t.Errorf("AddRow stored %q, want %q", tbl.Rows[0], want)
+	}
+	if err := tbl.AddRowStrict([]string{"4", "5", "6"}); err == nil {
+		t.Error("AddRowStrict accepted a row with too many cells")
+	}
+	if len(tbl.Rows) != 1 {
+		t.Errorf("AddRowStrict added the rejected row: %q", tbl.Rows)
+	}
+
+	// Rows appended directly to the exported field render without the extra cells
+	tbl.Rows = append(tbl.Rows, []string{"7", "8", "9"})
+	lines := renderLines(tbl)
+	if got := cells(lines[5]); len(got) != 2 || strings.Contains(lines[5], "9") {
+		t.Errorf("long row renders as %q, want its first two cells", lines[5])
+	}
+}
+
+func TestNarrowConsoleDescriptionKeepsRightBorder(t *testing.T) {
+	tbl := newTestTable("Identifier", "Package", "Version", "Severity", "Status")
+	tbl.SetANSISupport(true) // Fitting the console only applies to terminal output
+	tbl.SetConsoleWidth(40)
+	tbl.AddRow([]string{"CVE-2024-0001", "openssl", "3.0.2", "critical", "open"})
+	tbl.AddDescription(0, "A buffer overflow in the certificate parser allows remote attackers to execute arbitrary code")
+
+	lines := strings.Split(strings.TrimSuffix(StripANSI(tbl.Render()), "\n"), "\n")
+	edge := DisplayWidth(lines[0])
+	if edge > 40 {
+		t.Errorf("table is %d wide, want at most 40", edge)
+	}
+	described := 0
+	for _, line := range lines {
+		if r := []rune(line); !strings.ContainsRune("┐│┤┘", r[len(r)-1]) {
+			t.Errorf("line %q doesn't end with a border", line)
+		}
+		if DisplayWidth(line) != edge {
+			t.Errorf("line %q ends at %d, want %d", line, DisplayWidth(line), edge)
+		}
+		if strings.Contains(line, "attackers") || strings.Contains(line, "buffer") {
+			described++
+		}
+	}
+	if described == 0 {
+		t.Errorf("description not rendered:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestWrappedColorsReopenOnEachLine(t *testing.T) {
+	const red, green, reset = "\x1b[31m", "\x1b[32m", "\x1b[0m"
+	tbl := newTestTable("Status")
+	tbl.SetANSISupport(true)
+	tbl.SetDimBorder(false)
+	tbl.SetMaxWidth(0, 10)
+	tbl.AddRow([]string{red + "red words here" + reset + " " + green + "green words there" + reset})
+
+	lines := renderLines(tbl)
+	want := []string{
+		red + "red words" + reset,
+		red + "here" + reset + " " + green + "green" + reset,
+		green + "words" + reset,
+		green + "there" + reset,
+	}
+	var got []string
+	for _, line := range lines[3 : len(lines)-1] {
+		got = append(got, strings.TrimSpace(cells(line)[0]))
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestOSCSequencesTakeNoWidth(t *testing.T) {
+	st := "\x1b]8;;https://example.com/a\x1b\\documentation\x1b]8;;\x1b\\"
+	bel := "\x1b]8;;https://example.com/b\x07documentation\x1b]8;;\x07"
+	for _, link := range []string{st, bel} {
+		if w := DisplayWidth(link); w != len("documentation") {
+			t.Errorf("DisplayWidth(%q) = %d, want 13", link, w)
+		}
+	}
+
+	tbl := newTestTable("Link", "Note")
+	tbl.SetANSISupport(true)
+	tbl.SetDimBorder(false)
+	tbl.SetHeaderHighlighting(false)
+	tbl.AddRow([]string{st, "x"})
+	tbl.AddRow([]string{bel, "y"})
+	if got := tbl.GetColumnWidths()[0]; got != len("documentation") {
+		t.Errorf("link column is %d wide, want 13", got)
+	}
+	lines := renderLines(tbl)
+	for _, line := range lines {
+		if DisplayWidth(line) != DisplayWidth(lines[0]) {
+			t.Errorf("line %q doesn't line up with the top border", line)
+		}
+	}
+}
+
+func TestHyperlinkMeasuresAsItsText(t *testing.T) {
+	link := Hyperlink("https://example.com/docs", "read the fine manual")
+	if w := DisplayWidth(link); w != len("read the fine manual") {
+		t.Errorf("DisplayWidth = %d, want the text's 20", w)
+	}
+	if got := Hyperlink("https://x.io/\x1b\\", "a\x07b"); got != "\x1b]8;;https://x.io/\\\x1b\\ab\x1b]8;;\x1b\\" {
+		t.Errorf("Hyperlink kept control characters: %q", got)
+	}
+
+	tbl := newTestTable("Link")
+	tbl.SetANSISupport(true)
+	tbl.SetDimBorder(false)
+	tbl.SetHeaderHighlighting(false)
+	tbl.SetMaxWidth(0, 10)
+	tbl.AddRow([]string{link})
+	lines := renderLines(tbl)
+	var got []string
+	for _, line := range lines[3 : len(lines)-1] {
+		cell := strings.TrimSpace(cells(line)[0])
+		// Every wrapped line is a link of its own
+		if !strings.HasPrefix(cell, "\x1b]8;;https://example.com/docs\x1b\\") || !strings.HasSuffix(cell, "\x1b]8;;\x1b\\") {
+			t.Errorf("wrapped line %q isn't wrapped in the link", cell)
+		}
+		got = append(got, StripANSI(cell))
+	}
+	if want := []string{"read the", "fine", "manual"}; !slices.Equal(got, want) {
+		t.Errorf("wrapped text = %q, want %q", got, want)
+	}
+
+	tbl.SetANSISupport(false)
+	if got := strings.TrimSpace(cells(renderLines(tbl)[3])[0]); got != "read the" {
+		t.Errorf("without ANSI the first line is %q, want the plain text", got)
+	}
+}
+
+// TestConcurrentAddRowAndRender is meant to be run with -race
+func TestConcurrentAddRowAndRender(t *testing.T) {
+	tbl := newTestTable("Worker", "Item")
+	const workers, rows = 8, 50
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rows {
+				tbl.AddRow([]string{strconv.Itoa(w), strconv.Itoa(i)})
+				if i%10 == 0 {
+					tbl.AddDescription(i, "checkpoint")
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 20 {
+			tbl.Render()
+			tbl.GetColumnWidths()
+		}
+	}()
+	wg.Wait()
+
+	if n := tbl.RowCount(); n != workers*rows {
+		t.Errorf("RowCount() = %d, want %d", n, workers*rows)
+	}
+}
+
+func TestStreamRowsWritesRowsInOrder(t *testing.T) {
+	tbl := newTestTable("Seq", "Event")
+	tbl.SetColumnWidth(1, 12)
+
+	ch := make(chan []string)
+	go func() {
+		defer close(ch)
+		for i, event := range []string{"start", "progress", "done"} {
+			ch <- []string{strconv.Itoa(i), event}
+		}
+	}()
+	var buf bytes.Buffer
+	if err := tbl.StreamRows(context.Background(), ch, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	var events []string
+	for _, line := range lines[3:] {
+		if c := cells(line); c != nil {
+			events = append(events, strings.TrimSpace(c[1]))
+		}
+	}
+	if want := []string{"start", "progress", "done"}; !slices.Equal(events, want) {
+		t.Errorf("events = %q, want %q", events, want)
+	}
+	if last := lines[len(lines)-1]; !strings.HasPrefix(last, "└") {
+		t.Errorf("last line = %q, want the bottom border", last)
+	}
+	if len(tbl.Rows) != 0 {
+		t.Errorf("streamed rows were added to the table: %q", tbl.Rows)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	buf.Reset()
+	if err := tbl.StreamRows(ctx, make(chan []string), &buf); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled StreamRows error = %v, want context.Canceled", err)
+	}
+	if !strings.Contains(buf.String(), "└") {
+		t.Error("cancelled stream wasn't closed with the bottom border")
+	}
+}
+
+func TestAppendShiftsDescriptions(t *testing.T) {
+	base := newTestTable("Host", "Load")
+	base.AddRows([][]string{{"a", "1"}, {"b", "2"}, {"c", "3"}})
+	base.SetAlignment(1, "right")
+	base.AddDescription(0, "first")
+
+	other := newTestTable("Host", "Load")
+	other.AddRows([][]string{{"d", "4"}, {"e", "5"}})
+	other.AddDescriptionWithTitle(1, "Note", "fifth")
+
+	if err := base.Append(other); err != nil {
+		t.Fatal(err)
+	}
+	if len(base.Rows) != 5 || base.Rows[4][0] != "e" {
+		t.Fatalf("rows = %q, want the two appended after the three", base.Rows)
+	}
+	if got := base.Descriptions[4]; !slices.Equal(got, []string{"fifth"}) || !slices.Equal(base.DescriptionTitles[4], []string{"Note"}) {
+		t.Errorf("row 4 description = %q, %q, want other's row 1 description", got, base.DescriptionTitles[4])
+	}
+	if !slices.Equal(base.Descriptions[0], []string{"first"}) || len(base.Descriptions) != 2 {
+		t.Errorf("descriptions = %q, want rows 0 and 4 only", base.Descriptions)
+	}
+	if base.alignments[1] != "right" {
+		t.Errorf("alignment = %q, want the receiver's", base.alignments[1])
+	}
+
+	if err := base.Append(newTestTable("Host")); err == nil {
+		t.Error("Append accepted a table with different headers")
+	}
+}
+
+func TestAppendFillsHashColumns(t *testing.T) {
+	base := newTestTable("Package", "Version")
+	base.AddRow([]string{"openssl", "3.0.2"})
+	base.AddHashColumn("Hash", []int{0, 1})
+
+	other := newTestTable("Package", "Version", "Hash")
+	other.AddRows([][]string{{"openssl", "3.0.2", ""}, {"zlib", "1.3", "stale"}})
+	if err := base.Append(other); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := base.Rows[1][2], base.Rows[0][2]; got != want {
+		t.Errorf("appended row hash = %q, want %q like the identical row", got, want)
+	}
+	if got := base.Rows[2][2]; len(got) != 8 || got == base.Rows[0][2] {
+		t.Errorf("appended row hash = %q, want its own 8 hex digits", got)
+	}
+	if _, ok := base.visibleCache["zlib"]; !ok {
+		t.Error("appended cells weren't measured like added rows")
+	}
+}
+
+func TestGroupAlignmentAppliesToMembers(t *testing.T) {
+	g := NewGroup()
+	first, second := newTestTable("Host", "Load"), newTestTable("Host", "Load")
+	first.AddRow([]string{"alpha", "1"})
+	second.AddRow([]string{"beta", "250"})
+	first.SetTitle("East")
+	second.SetTitle("West")
+	g.Add(first)
+	g.Add(second)
+
+	g.SetAlignment(1, "right")
+	for _, tbl := range g.GetTables() {
+		if tbl.alignments[1] != "right" {
+			t.Errorf("member alignment = %q, want right", tbl.alignments[1])
+		}
+	}
+
+	blocks := strings.Split(strings.TrimSuffix(g.RenderAll(), "\n"), "\n\n")
+	if len(blocks) != 2 {
+		t.Fatalf("got %d blocks, want two separated by a blank line", len(blocks))
+	}
+	for i, title := range []string{"East", "West"} {
+		lines := strings.Split(blocks[i], "\n")
+		if strings.TrimSpace(lines[0]) != title {
+			t.Errorf("block %d starts with %q, want its title", i, lines[0])
+		}
+	}
+	if got := cells(strings.Split(blocks[0], "\n")[4])[1]; got != "    1 " {
+		t.Errorf("first table's load cell = %q, want it right-aligned", got)
+	}
+}
+
+func TestSyncMismatchedColumnCounts(t *testing.T) {
+	narrow := newTestTable("Host", "Load")
+	narrow.AddRow([]string{"a", "1"})
+	wide := newTestTable("Host", "Load", "Region")
+	wide.AddRow([]string{"a-long-hostname", "1", "eu"})
+	g := NewGroup()
+	g.Add(narrow)
+	g.Add(wide)
+
+	if err := g.SyncColumnWidthsE(); err == nil {
+		t.Fatal("SyncColumnWidthsE accepted tables with 2 and 3 columns")
+	}
+	if got := narrow.GetColumnWidths(); got[0] != len("Host") {
+		t.Errorf("failed sync changed the widths to %v", got)
+	}
+
+	// The leading columns are synced, the extra one is left to its table
+	g.SyncColumnWidths()
+	n, w := renderLines(narrow), renderLines(wide)
+	if DisplayWidth(cells(n[3])[0]) != DisplayWidth(cells(w[3])[0]) {
+		t.Errorf("column 0 not synced:\n%s\n%s", n[3], w[3])
+	}
+	if got := strings.TrimSpace(cells(w[3])[2]); got != "eu" {
+		t.Errorf("extra column renders %q", got)
+	}
+}
+
+func TestRenderSideBySideJoinsLines(t *testing.T) {
+	left, right := newTestTable("Host"), newTestTable("Zone")
+	left.SetANSISupport(true) // Colored lines must be padded by their visible width
+	left.AddRows([][]string{{"a1"}, {"a2"}, {"a3"}})
+	right.AddRows([][]string{{"b1"}, {"b2"}, {"b3"}})
+	g := NewGroup()
+	g.Add(left)
+	g.Add(right)
+
+	lines := strings.Split(strings.TrimSuffix(g.RenderSideBySide(3), "\n"), "\n")
+	if len(lines) != 9 {
+		t.Fatalf("got %d lines, want 9:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	leftWidth := DisplayWidth(strings.Split(left.Render(), "\n")[0])
+	for i, line := range lines {
+		plain := StripANSI(line)
+		// The right table starts after the left one and the gap on every line
+		if r := []rune(plain); len(r) <= leftWidth+3 || r[leftWidth+3] == ' ' || string(r[leftWidth:leftWidth+3]) != "   " {
+			t.Errorf("line %d %q doesn't start the right table at column %d", i, plain, leftWidth+3)
+		}
+	}
+	for i := 1; i <= 3; i++ {
+		row := StripANSI(lines[1+2*i])
+		if !strings.Contains(row, "a"+strconv.Itoa(i)) || !strings.Contains(row, "b"+strconv.Itoa(i)) {
+			t.Errorf("line %q doesn't hold row %d of both tables", row, i)
+		}
+	}
+}
+
+func TestRowCountOptionsHeaderAndStart(t *testing.T) {
+	tbl := newTestTable("Host")
+	tbl.SetRowCountOptions("No.", 0)
+	tbl.EnableRowCount(true)
+	for range 11 {
+		tbl.AddRow([]string{"x"})
+	}
+
+	lines := renderLines(tbl)
+	if got := strings.TrimSpace(cells(lines[1])[0]); got != "No." {
+		t.Errorf("index header = %q, want \"No.\"", got)
+	}
+	// Numbers are right-aligned in the index column
+	if first, last := cells(lines[3])[0], cells(lines[len(lines)-2])[0]; first != "   0 " || last != "  10 " {
+		t.Errorf("first and last index cells = %q, %q, want 0 and 10 right-aligned", first, last)
+	}
+	if len(tbl.Headers) != 1 {
+		t.Errorf("numbering changed the stored headers to %q", tbl.Headers)
+	}
+}
+
+func TestRowCountKeepsShiftedMaxWidth(t *testing.T) {
+	tbl := newTestTable("Message")
+	tbl.SetMaxWidth(0, 8)
+	tbl.SetTitle("Log")
+	tbl.EnableRowCount(true)
+	tbl.AddRow([]string{"connection reset by peer"})
+
+	lines := renderLines(tbl)
+	if strings.TrimSpace(lines[0]) != "Log" {
+		t.Errorf("first line = %q, want the title carried over", lines[0])
+	}
+	// The cap set on column 0 now applies to column 1
+	for _, line := range lines[4 : len(lines)-1] {
+		if w := DisplayWidth(cells(line)[1]); w != 8+2 {
+			t.Errorf("message cell %q is %d wide, want the 8 column cap plus padding", cells(line)[1], w)
+		}
+	}
+	if len(lines) < 7 {
+		t.Errorf("message wasn't wrapped at the cap:\n%s", strings.Join(lines, "\n"))
+	}
+
+	// The numbered copy keeps the ANSI setting instead of detecting it again
+	t.Setenv("NO_COLOR", "1")
+	tbl.SetANSISupport(true)
+	if !strings.Contains(tbl.Render(), "\x1b[") {
+		t.Error("numbered table dropped the ANSI support set on it")
+	}
+}
+
+func TestJSONRoundTripRendersIdentically(t *testing.T) {
+	tbl := newTestTable("Host", "Load")
+	tbl.SetTitle("Fleet")
+	tbl.SetAlignment(1, "right")
+	tbl.AddRows([][]string{{"alpha", "25"}, {"beta", "250"}})
+	tbl.AddDescriptionWithTitle(1, "Note", "draining")
+	tbl.AddDescriptionStyled(1, "", "since monday", "", "center")
+
+	data, err := json.Marshal(tbl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded *Table
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	// Display settings aren't serialized
+	decoded.SetANSISupport(false)
+	decoded.SetConsoleWidth(80)
+	decoded.SetBorderChars(UnicodeBorderChars)
+	if got, want := decoded.Render(), tbl.Render(); got != want {
+		t.Errorf("decoded table renders\n%s\nwant\n%s", got, want)
+	}
+
+	// Decoding into a grouped table keeps it in its group
+	g := NewGroup()
+	member := newTestTable("Old")
+	g.Add(member)
+	if err := json.Unmarshal(data, member); err != nil {
+		t.Fatal(err)
+	}
+	if member.group != g || !slices.Equal(member.Headers, []string{"Host", "Load"}) {
+		t.Errorf("decoded member has group %p and headers %q", member.group, member.Headers)
+	}
+	member.AddRow([]string{"gamma", "1"}) // The lock still works
+}
+
+// isASCII reports whether s holds only ASCII bytes
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestASCIIModeRendersOnlyASCII(t *testing.T) {
+	tbl := newTestTable("Item", "Cost")
+	tbl.SetANSISupport(true) // ASCII mode applies with ANSI support too
+	tbl.AddRows([][]string{{"a", "1"}, {"b", "2"}, {"Total", "3"}})
+	tbl.AddDescription(0, "note")
+	tbl.SetSeparatorBefore(2, "double")
+	tbl.SetHeaderSeparatorBars(map[int]float64{0: 0.5})
+	tbl.SetBarColumn(1, 3)
+	tbl.SetASCIIMode(true)
+
+	out := tbl.Render()
+	if !isASCII(out) {
+		t.Errorf("output isn't pure ASCII:\n%s", out)
+	}
+	if !strings.HasPrefix(StripANSI(out), "+--") || !strings.Contains(StripANSI(out), "| Item") {
+		t.Errorf("grid isn't drawn:\n%s", StripANSI(out))
+	}
+
+	tbl.SetASCIIMode(false)
+	if isASCII(tbl.Render()) {
+		t.Error("disabling ASCII mode didn't restore box drawing characters")
+	}
+}
+
+func TestNonUTF8LocaleDefaultsToASCII(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+	t.Setenv("LANG", "C")
+	tbl := NewTable([]string{"Host"})
+	tbl.SetANSISupport(false)
+	tbl.AddRow([]string{"alpha"})
+
+	if tbl.borderChars != ASCIIBorderChars {
+		t.Errorf("border chars = %+v, want ASCII", tbl.borderChars)
+	}
+	if out := tbl.Render(); !isASCII(out) || !strings.HasPrefix(out, "+-------+") {
+		t.Errorf("output isn't drawn in ASCII:\n%s", out)
+	}
+	tbl.SetBorderChars(UnicodeBorderChars)
+	if !strings.HasPrefix(tbl.Render(), "┌") {
+		t.Error("SetBorderChars didn't override the locale default")
+	}
+
+	t.Setenv("LC_ALL", "en_US.UTF-8")
+	if got := NewTable([]string{"Host"}).borderChars; got != UnicodeBorderChars {
+		t.Errorf("LC_ALL=en_US.UTF-8 gives border chars %+v, want box drawing", got)
+	}
+}
+
+func TestRenderColumnsProjectsInOrder(t *testing.T) {
+	tbl := newTestTable("ID", "Secret", "Name")
+	tbl.AddRows([][]string{{"1", "hunter2", "alpha"}, {"2", "swordfish", "beta"}})
+	tbl.AddDescription(1, "replica")
+
+	out, err := tbl.RenderColumns([]int{2, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	for i, want := range map[int][]string{1: {"Name", "ID"}, 3: {"alpha", "1"}, 5: {"beta", "2"}} {
+		got := cells(lines[i])
+		if len(got) != 2 || strings.TrimSpace(got[0]) != want[0] || strings.TrimSpace(got[1]) != want[1] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want)
+		}
+	}
+	for _, hidden := range []string{"Secret", "hunter2", "swordfish"} {
+		if strings.Contains(out, hidden) {
+			t.Errorf("output contains the omitted column's %q", hidden)
+		}
+	}
+	if !strings.Contains(out, "replica") {
+		t.Error("description of the projected row is missing")
+	}
+	if len(tbl.Headers) != 3 {
+		t.Errorf("projecting changed the table's headers to %q", tbl.Headers)
+	}
+
+	if _, err := tbl.RenderColumns([]int{0, 3}); err == nil {
+		t.Error("RenderColumns accepted an out-of-range column")
+	}
+}
+
+func TestReorderColumnsReverses(t *testing.T) {
+	tbl := newTestTable("A", "B", "C")
+	tbl.AddRows([][]string{{"a1", "b1", "c1"}, {"a2", "b2", "c2"}})
+	tbl.SetAlignment(0, "right")
+	tbl.SetMaxWidth(0, 5)
+
+	if err := tbl.ReorderColumns([]int{2, 1, 0}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"C", "B", "A"}; !slices.Equal(tbl.Headers, want) {
+		t.Errorf("headers = %q, want %q", tbl.Headers, want)
+	}
+	want := [][]string{{"c1", "b1", "a1"}, {"c2", "b2", "a2"}}
+	if !slices.EqualFunc(tbl.Rows, want, slices.Equal[[]string]) {
+		t.Errorf("rows = %q, want %q", tbl.Rows, want)
+	}
+	if tbl.alignments[2] != "right" || tbl.alignments[0] != "left" || tbl.maxWidths[2] != 5 {
+		t.Errorf("alignments = %q, max widths = %v, want them moved with column A", tbl.alignments, tbl.maxWidths)
+	}
+
+	for _, order := range [][]int{{0, 1}, {0, 0, 1}, {0, 1, 3}} {
+		if err := tbl.ReorderColumns(order); err == nil {
+			t.Errorf("ReorderColumns(%v) accepted a non-permutation", order)
+		}
+	}
+}
+
+func TestTransposeSwapsRowsAndColumns(t *testing.T) {
+	tbl := newTestTable("Name", "CPU", "Memory")
+	tbl.AddRows([][]string{{"web", "2", "4G"}, {"db", "8", "32G"}})
+	tbl.AddDescription(0, "frontend")
+
+	tt := tbl.Transpose()
+	if tt.RowCount() != 3 || tt.ColumnCount() != 3 {
+		t.Fatalf("transposed table is %dx%d, want 3 rows of 3 columns", tt.RowCount(), tt.ColumnCount())
+	}
+	if want := []string{"", "Row 1", "Row 2"}; !slices.Equal(tt.Headers, want) {
+		t.Errorf("headers = %q, want %q", tt.Headers, want)
+	}
+	want := [][]string{{"Name", "web", "db"}, {"CPU", "2", "8"}, {"Memory", "4G", "32G"}}
+	if !slices.EqualFunc(tt.Rows, want, slices.Equal[[]string]) {
+		t.Errorf("rows = %q, want %q", tt.Rows, want)
+	}
+	if len(tt.Descriptions) != 0 {
+		t.Errorf("descriptions = %q, want them dropped", tt.Descriptions)
+	}
+	if !strings.HasPrefix(tt.Render(), "┌") {
+		t.Error("transposed table doesn't keep the border settings")
+	}
+}
+
+func TestMaxDisplayRowsSummarizesTheRest(t *testing.T) {
+	tbl := newTestTable("Index", "Event")
+	for i := range 10 {
+		tbl.AddRow([]string{strconv.Itoa(i), "event"})
+	}
+	tbl.AddDescription(5, "past the limit")
+	tbl.SetMaxDisplayRows(3)
+
+	lines := renderLines(tbl)
+	var shown []string
+	for _, line := range lines[3 : len(lines)-2] {
+		if c := cells(line); c != nil {
+			shown = append(shown, strings.TrimSpace(c[0]))
+		}
+	}
+	if want := []string{"0", "1", "2"}; !slices.Equal(shown, want) {
+		t.Errorf("rows shown = %q, want %q", shown, want)
+	}
+	summary := lines[len(lines)-2]
+	if !strings.Contains(summary, "7 more rows") || DisplayWidth(summary) != DisplayWidth(lines[0]) {
+		t.Errorf("summary line = %q, want a full-width \"7 more rows\"", summary)
+	}
+	if out := strings.Join(lines, "\n"); strings.Contains(out, "past the limit") {
+		t.Error("description of a hidden row was rendered")
+	}
+	if len(tbl.Rows) != 10 {
+		t.Errorf("limit changed the stored rows to %d", len(tbl.Rows))
+	}
+}
+
+func TestRenderPageShowsSecondPage(t *testing.T) {
+	tbl := newTestTable("Index", "Name")
+	for i, name := range []string{"alpha", "beta", "gamma", "delta", "a-much-longer-name"} {
+		tbl.AddRow([]string{strconv.Itoa(i), name})
+	}
+	tbl.AddDescription(3, "on page two")
+
+	out, err := tbl.RenderPage(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if strings.TrimSpace(cells(lines[1])[1]) != "Name" {
+		t.Errorf("page doesn't start with the header: %q", lines[1])
+	}
+	var names []string
+	for _, line := range lines[3:] {
+		if c := cells(line); c != nil && strings.TrimSpace(c[0]) != "" {
+			names = append(names, strings.TrimSpace(c[1]))
+		}
+	}
+	if want := []string{"gamma", "delta"}; !slices.Equal(names, want) {
+		t.Errorf("page 2 rows = %q, want %q", names, want)
+	}
+	if !strings.Contains(out, "on page two") {
+		t.Error("description of a row on the page is missing")
+	}
+	// Widths come from the whole table, the last row included
+	if w := DisplayWidth(cells(lines[1])[1]); w != len(" a-much-longer-name ") {
+		t.Errorf("name column is %d wide, want the width over all pages", w)
+	}
+
+	if _, err := tbl.RenderPage(4, 2); err == nil {
+		t.Error("RenderPage accepted page 4 of 3")
+	}
+	if _, err := tbl.RenderPage(1, 0); err == nil {
+		t.Error("RenderPage accepted a page size of 0")
+	}
+}
+
+func TestHeaderRepeatsEveryFourRows(t *testing.T) {
+	tbl := newTestTable("Index", "Event")
+	for i := range 10 {
+		tbl.AddRow([]string{strconv.Itoa(i), "event"})
+	}
+	tbl.AddDescription(3, "closes the first block")
+	tbl.SetHeaderRepeat(4)
+
+	lines := renderLines(tbl)
+	var headers []int
+	for i, line := range lines {
+		if strings.Contains(line, "Index") {
+			headers = append(headers, i)
+		}
+	}
+	if len(headers) != 3 {
+		t.Fatalf("header appears on lines %v, want three times:\n%s", headers, strings.Join(lines, "\n"))
+	}
+	for _, i := range headers[1:] {
+		// Each repeated header sits between two full separators, with the
+		// row it precedes right after
+		if lines[i-1] != lines[2] || lines[i+1] != lines[2] {
+			t.Errorf("repeated header on line %d is framed by %q and %q", i, lines[i-1], lines[i+1])
+		}
+	}
+	if got := strings.TrimSpace(cells(lines[headers[1]+2])[0]); got != "4" {
+		t.Errorf("row after the second header = %q, want 4", got)
+	}
+}
+
+func TestExactWidthSizesEveryLine(t *testing.T) {
+	for name, rows := range map[string][][]string{
+		"expand": {{"1", "ok"}},
+		"shrink": {{"1", strings.Repeat("a long status message ", 5)}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tbl := newTestTable("ID", "Status")
+			tbl.AddRows(rows)
+			tbl.AddDescription(0, "a description spanning the data columns")
+			tbl.SetExactWidth(60)
+
+			for _, line := range renderLines(tbl) {
+				if w := DisplayWidth(line); w != 60 {
+					t.Errorf("line %q is %d wide, want 60", line, w)
+				}
+			}
+		})
+	}
+}
+
+func TestColumnWidthPercentTakesHalfTheContent(t *testing.T) {
+	tbl := newTestTable("Name", "Status", "Notes")
+	tbl.SetConsoleWidth(100)
+	tbl.AddRow([]string{"api", "ok", "healthy"})
+	if err := tbl.SetColumnWidthPercent(2, 50); err != nil {
+		t.Fatal(err)
+	}
+
+	tbl.Render()
+	// 100 columns less 4 borders and 3 padded cells leave 90 for content
+	widths := tbl.GetColumnWidths()
+	if widths[2] != 45 {
+		t.Errorf("column widths = %v, want column 2 at 45", widths)
+	}
+	if widths[0] != 4 || widths[1] != 6 {
+		t.Errorf("column widths = %v, want the others content-fit at 4 and 6", widths)
+	}
+
+	if err := tbl.SetColumnWidthPercent(0, 60); err == nil {
+		t.Error("SetColumnWidthPercent accepted percentages summing to 110")
+	}
+	for _, pct := range []float64{-10, math.NaN(), math.Inf(1)} {
+		if err := tbl.SetColumnWidthPercent(0, pct); err == nil {
+			t.Errorf("SetColumnWidthPercent accepted %v", pct)
+		}
+	}
+	if got := tbl.GetColumnWidths()[0]; got != 4 {
+		t.Errorf("rejected percentages changed column 0 to %d", got)
+	}
+}
+
+func TestShowDescriptionsToggle(t *testing.T) {
+	tbl := newTestTable("ID", "Name")
+	tbl.AddRow([]string{"1", "alpha"})
+	tbl.AddRow([]string{"2", "beta"})
+	tbl.AddDescription(0, "first advisory")
+
+	tbl.SetShowDescriptions(false)
+	want := []string{
+		"┌────┬───────┐",
+		"│ ID │ Name  │",
+		"├────┼───────┤",
+		"│ 1  │ alpha │",
+		"├────┼───────┤",
+		"│ 2  │ beta  │",
+		"└────┴───────┘",
+	}
+	if got := renderLines(tbl); !slices.Equal(got, want) {
+		t.Errorf("descriptions off rendered\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	tbl.SetShowDescriptions(true)
+	if out := tbl.Render(); !strings.Contains(out, "advisory") {
+		t.Errorf("descriptions back on are missing the advisory:\n%s", out)
+	}
+}
+
+func TestDescriptionBulletAlignsWrappedLines(t *testing.T) {
+	tbl := newTestTable("ID", "Name")
+	tbl.AddRow([]string{"1", "alpha beta gamma"})
+	tbl.AddDescription(0, "first advisory text that wraps a few times here")
+	tbl.SetDescriptionBullet("• ", 2)
+
+	lines := renderLines(tbl)
+	// The description starts below its separator, after the data row
+	var desc []string
+	for _, line := range lines[5 : len(lines)-1] {
+		desc = append(desc, cells(line)[1])
+	}
+	if len(desc) < 2 {
+		t.Fatalf("description didn't wrap:\n%s", strings.Join(lines, "\n"))
+	}
+	if !strings.HasPrefix(desc[0], "   • first") {
+		t.Errorf("first description line = %q, want the indented bullet then the text", desc[0])
+	}
+	for _, line := range desc[1:] {
+		// Padding, indent and the bullet's width, then the text
+		if !strings.HasPrefix(line, "     ") || line[5] == ' ' {
+			t.Errorf("continuation line %q isn't aligned under the text", line)
+		}
+	}
+}
+
+func TestStyledDescriptionRightAlignedAndColored(t *testing.T) {
+	tbl := newTestTable("ID", "Name")
+	tbl.SetANSISupport(true)
+	tbl.AddRow([]string{"1", "alpha beta gamma delta"})
+	tbl.AddDescriptionStyled(0, "", "note", "\x1b[31m", "right")
+
+	lines := strings.Split(tbl.Render(), "\n")
+	desc := lines[5]
+	if !strings.Contains(desc, strings.Repeat(" ", 19)+"\x1b[31mnote\x1b[0m \x1b") {
+		t.Errorf("description line = %q, want red note padded to the right edge", desc)
+	}
+	if w := DisplayWidth(desc); w != DisplayWidth(lines[3]) {
+		t.Errorf("description line is %d wide, want %d like the data row", w, DisplayWidth(lines[3]))
+	}
+}
+
+func TestDiffMarksModifiedCell(t *testing.T) {
+	oldTable := newTestTable("Name", "Version")
+	oldTable.AddRows([][]string{{"openssl", "3.0"}, {"zlib", "1.2"}, {"curl", "8.0"}})
+	newTable := newTestTable("Name", "Version")
+	newTable.AddRows([][]string{{"openssl", "3.1"}, {"zlib", "1.2"}, {"curl", "8.0"}})
+
+	d, err := Diff(oldTable, newTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := renderLines(d)
+	want := []string{"│ openssl │ ~ 3.1   │", "│ zlib    │ 1.2     │", "│ curl    │ 8.0     │"}
+	for i, w := range want {
+		if got := lines[3+2*i]; got != w {
+			t.Errorf("row %d = %q, want %q", i, got, w)
+		}
+	}
+
+	newTable.SetANSISupport(true)
+	d, err = Diff(oldTable, newTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := d.Render()
+	if !strings.Contains(out, diffModifiedColor+"3.1\x1b[0m") {
+		t.Errorf("modified cell isn't yellow in\n%q", out)
+	}
+	if strings.Contains(StripANSI(out), "~") || strings.Count(out, diffModifiedColor) != 1 {
+		t.Errorf("only the modified cell should be marked, by color alone, in\n%q", out)
+	}
+
+	if _, err := Diff(oldTable, newTestTable("Name", "Release")); err == nil {
+		t.Error("Diff accepted tables with different headers")
+	}
+}
+
+func TestTreeRowsDrawBranchConnectors(t *testing.T) {
+	tbl := newTestTable("Package", "Version")
+	tbl.AddTreeRow(0, []string{"app", "1.0"})
+	tbl.AddTreeRow(1, []string{"libfoo", "2.1"})
+	tbl.AddTreeRow(2, []string{"zlib", "1.3"})
+	tbl.AddTreeRow(1, []string{"libbar", "0.9"})
+
+	for _, tc := range []struct {
+		ansi bool
+		want []string
+	}{
+		{false, []string{"│ app        │", "│ |- libfoo  │", "│ |  `- zlib │", "│ `- libbar  │"}},
+		{true, []string{"│ app        │", "│ ├─ libfoo  │", "│ │  └─ zlib │", "│ └─ libbar  │"}},
+	} {
+		tbl.SetANSISupport(tc.ansi)
+		lines := strings.Split(strings.TrimSuffix(StripANSI(tbl.Render()), "\n"), "\n")
+		for i, want := range tc.want {
+			// The first cells line up, so each row has the same prefix
+			if got := lines[3+2*i]; !strings.HasPrefix(got, want) {
+				t.Errorf("ANSI %v: row %d = %q, want it to start %q", tc.ansi, i, got, want)
+			}
+		}
+	}
+}
+
+func TestRenderGroupedAddsSubtotals(t *testing.T) {
+	tbl := newTestTable("Team", "Name", "Hours")
+	tbl.AddRows([][]string{{"ops", "ann", "3"}, {"dev", "bob", "5"}, {"ops", "cid", "4"}, {"dev", "dee", "1"}})
+
+	var groups [][]string
+	sum := func(rows [][]string) []string {
+		var names []string
+		hours := 0
+		for _, row := range rows {
+			names = append(names, row[1])
+			h, _ := strconv.Atoi(row[2])
+			hours += h
+		}
+		groups = append(groups, names)
+		return []string{"", "total", strconv.Itoa(hours)}
+	}
+
+	lines := strings.Split(strings.TrimSuffix(tbl.RenderGrouped(0, sum), "\n"), "\n")
+	want := [][]string{{"bob", "dee"}, {"ann", "cid"}, {"ann", "bob", "cid", "dee"}}
+	if len(groups) != len(want) {
+		t.Fatalf("aggregate called with %v, want two groups and the grand total", groups)
+	}
+	for i := range want {
+		slices.Sort(groups[i])
+		if !slices.Equal(groups[i], want[i]) {
+			t.Errorf("aggregate call %d got rows %v, want %v", i, groups[i], want[i])
+		}
+	}
+
+	wantTotals := []string{"│      │ total │ 6     │", "│      │ total │ 7     │", "│      │ total │ 13    │"}
+	var totals []string
+	for i, line := range lines {
+		if strings.Contains(line, "total") {
+			totals = append(totals, line)
+			if !strings.HasPrefix(lines[i-1], "╞") {
+				t.Errorf("total row %q isn't set off by a double separator", line)
+			}
+		}
+	}
+	if !slices.Equal(totals, wantTotals) {
+		t.Errorf("total rows = %q, want %q", totals, wantTotals)
+	}
+	if got := cells(lines[3])[0]; got != " dev  " {
+		t.Errorf("first group starts with %q, want the dev rows sorted first", got)
+	}
+}
+
+func TestColumnMinWidthLimitsShrinking(t *testing.T) {
+	newTight := func() *Table {
+		tbl := newTestTable("ID", "Package", "Description")
+		tbl.SetANSISupport(true)
+		tbl.SetConsoleWidth(22)
+		tbl.AddRow([]string{"CVE-2024-0001", "openssl-libs", strings.Repeat("long text ", 6)})
+		return tbl
+	}
+
+	tbl := newTight()
+	tbl.Render()
+	if got := tbl.GetColumnWidths()[1]; got >= 8 {
+		t.Fatalf("column 1 shrank only to %d, the console isn't tight enough", got)
+	}
+
+	tbl = newTight()
+	tbl.SetColumnMinWidth(1, 8)
+	lines := strings.Split(StripANSI(tbl.Render()), "\n")
+	if got := tbl.GetColumnWidths(); got[1] < 8 {
+		t.Errorf("column widths = %v, want column 1 at least 8", got)
+	}
+	// With every column at its minimum the table overflows the console
+	if w := DisplayWidth(lines[0]); w <= 22 {
+		t.Errorf("table is %d wide, want it to overflow the 22-column console", w)
+	}
+}
+
+func TestRenderPlainAlignsColumnsWithoutBorders(t *testing.T) {
+	tbl := newTestTable("Name", "Version", "Status")
+	tbl.AddRows([][]string{{"openssl", "3.0.1", "ok"}, {"zlib", "1.2", "outdated"}})
+
+	out := tbl.RenderPlain()
+	want := "Name     Version  Status\n" +
+		"-------  -------  --------\n" +
+		"openssl  3.0.1    ok\n" +
+		"zlib     1.2      outdated\n"
+	if out != want {
+		t.Errorf("RenderPlain() =\n%s\nwant\n%s", out, want)
+	}
+	if strings.ContainsAny(out, "│┌┐└┘├┤┬┴┼─|+") {
+		t.Errorf("RenderPlain() drew box glyphs:\n%s", out)
+	}
+
+	tbl.SetHeaderSeparator(false)
+	if lines := strings.Split(tbl.RenderPlain(), "\n"); strings.HasPrefix(lines[1], "-") {
+		t.Errorf("hidden header separator still underlines the headers: %q", lines[1])
+	}
+}
+
+func TestRenderLaTeXSpecAndEscaping(t *testing.T) {
+	tbl := newTestTable("Item", "Cost", "Note")
+	tbl.SetAlignment(1, "right")
+	tbl.SetAlignment(2, "center")
+	tbl.AddRow([]string{"R&D_1", "$5", "50% #1 {x} ~^\\"})
+	tbl.AddRow([]string{"\x1b[31mred\x1b[0m", "1", "ok"})
+	tbl.AddDescription(0, "a note")
+
+	want := `\begin{tabular}{|l|r|c|}
+\hline
+Item & Cost & Note \\
+\hline
+R\&D\_1 & \$5 & 50\% \#1 \{x\} \textasciitilde{}\textasciicircum{}\textbackslash{} \\
+\multicolumn{3}{|l|}{a note} \\
+red & 1 & ok \\
+\hline
+\end{tabular}
+`
+	if got := tbl.RenderLaTeX(); got != want {
+		t.Errorf("RenderLaTeX() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderGridRSTIsValidGrid(t *testing.T) {
+	tbl := newTestTable("Name", "Summary")
+	tbl.SetANSISupport(true)
+	tbl.SetMaxWidth(1, 12)
+	tbl.AddRow([]string{"openssl", "\x1b[31ma summary that wraps over lines\x1b[0m"})
+	tbl.AddRow([]string{"zlib", "ok"})
+
+	lines := strings.Split(strings.TrimSuffix(tbl.RenderGridRST(), "\n"), "\n")
+	if lines[2] != "+=========+==============+" {
+		t.Errorf("header underline = %q, want it drawn with =", lines[2])
+	}
+
+	// In a grid table every line has its column boundaries at the same
+	// offsets: "+" on rules of "-" or "=", and "|" on cell lines
+	boundaries := func(line string, sep byte) []int {
+		var at []int
+		for i := 0; i < len(line); i++ {
+			if line[i] == sep {
+				at = append(at, i)
+			}
+		}
+		return at
+	}
+	columns := boundaries(lines[0], '+')
+	for i, line := range lines {
+		if !isASCII(line) {
+			t.Errorf("line %d %q isn't ASCII", i, line)
+		}
+		switch line[0] {
+		case '+':
+			if !slices.Equal(boundaries(line, '+'), columns) ||
+				strings.Trim(line, "+-") != "" && strings.Trim(line, "+=") != "" {
+				t.Errorf("rule %d %q doesn't match the grid", i, line)
+			}
+			if strings.Contains(line, "=") && i != 2 {
+				t.Errorf("rule %d %q uses = below a data row", i, line)
+			}
+		case '|':
+			if !slices.Equal(boundaries(line, '|'), columns) {
+				t.Errorf("cell line %d %q doesn't match the grid", i, line)
+			}
+		default:
+			t.Errorf("line %d %q isn't part of a grid table", i, line)
+		}
+	}
+	if last := lines[len(lines)-1]; last[0] != '+' {
+		t.Errorf("grid ends with %q, want a closing rule", last)
+	}
+}
+
+func TestFootnotesFollowBottomBorder(t *testing.T) {
+	tbl := newTestTable("Name", "Version")
+	tbl.AddRow([]string{"openssl", "3.0"})
+	tbl.AddFootnote("Data as of today.")
+	tbl.AddFootnote("Versions from the distro index, which can lag behind upstream")
+
+	lines := renderLines(tbl)
+	want := []string{
+		"└─────────┴─────────┘",
+		"Data as of today.",
+		"Versions from the",
+		"distro index, which",
+		"can lag behind",
+		"upstream",
+	}
+	if got := lines[4:]; !slices.Equal(got, want) {
+		t.Errorf("table ends\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	tbl.SetANSISupport(true)
+	if out := tbl.Render(); !strings.Contains(out, "\n\x1b[2m\x1b[38;5;240mData as of today.\x1b[0m\n") {
+		t.Errorf("footnote isn't dimmed on its own line in\n%q", out)
+	}
+}
+
+func TestRenderBytesMatchesRender(t *testing.T) {
+	tbl := newTestTable("Host", "Load")
+	tbl.SetTitle("Servers")
+	tbl.AddRows([][]string{{"alpha", "25"}, {"beta", "50"}})
+	tbl.AddDescription(1, "draining")
+
+	for _, ansi := range []bool{false, true} {
+		tbl.SetANSISupport(ansi)
+		if got, want := string(tbl.RenderBytes()), tbl.Render(); got != want {
+			t.Errorf("ANSI %v: RenderBytes() =\n%q\nRender() =\n%q", ansi, got, want)
+		}
+	}
+}
+
+func TestLayoutCacheNoticesChanges(t *testing.T) {
+	tbl := newTestTable("Host", "Status")
+	tbl.AddRow([]string{"alpha", "ok"})
+	tbl.Render()
+	if got := tbl.GetColumnWidths(); !slices.Equal(got, []int{5, 6}) {
+		t.Fatalf("column widths = %v, want [5 6]", got)
+	}
+
+	tbl.AddRow([]string{"beta", "degraded"})
+	tbl.Render()
+	if got := tbl.GetColumnWidths(); !slices.Equal(got, []int{5, 8}) {
+		t.Errorf("after AddRow column widths = %v, want [5 8]", got)
+	}
+
+	// Edits to the exported fields don't go through a setter
+	tbl.Rows[0][0] = "gamma-west"
+	lines := renderLines(tbl)
+	if got := tbl.GetColumnWidths(); !slices.Equal(got, []int{10, 8}) {
+		t.Errorf("after editing Rows column widths = %v, want [10 8]", got)
+	}
+	if lines[3] != "│ gamma-west │ ok       │" {
+		t.Errorf("edited row = %q", lines[3])
+	}
+
+	tbl.SetMaxWidth(0, 6)
+	tbl.Render()
+	if got := tbl.GetColumnWidths(); !slices.Equal(got, []int{6, 8}) {
+		t.Errorf("after SetMaxWidth column widths = %v, want [6 8]", got)
+	}
+}
+
+// BenchmarkRenderUnchanged renders the same table repeatedly, reusing the
+// cached layout, against recomputing it on every render as before
+func BenchmarkRenderUnchanged(b *testing.B) {
+	tbl := newTestTable("ID", "Package", "Version", "Description")
+	for i := range 200 {
+		n := strconv.Itoa(i)
+		tbl.AddRow([]string{n, "package-" + n, "1.2." + n, "a short description"})
+	}
+
+	b.Run("cached", func(b *testing.B) {
+		for range b.N {
+			tbl.Render()
+		}
+	})
+	b.Run("recomputed", func(b *testing.B) {
+		for range b.N {
+			tbl.InvalidateLayout()
+			tbl.Render()
+		}
+	})
+}
+
+// BenchmarkRenderColored renders a table of heavily ANSI-colored cells,
+// measuring them from the cache of stripped cells against stripping every
+// cell again with the regexp on each render
+func BenchmarkRenderColored(b *testing.B) {
+	tbl := newTestTable("ID", "Package", "Severity", "Description")
+	tbl.SetANSISupport(true)
+	for i := range 200 {
+		n := strconv.Itoa(i)
+		tbl.AddRow([]string{
+			"\x1b[1m" + n + "\x1b[0m",
+			"\x1b[36mpackage-\x1b[1m" + n + "\x1b[0m",
+			"\x1b[41m\x1b[97mCRITICAL\x1b[0m",
+			"\x1b[33ma \x1b[4mcolored\x1b[24m description\x1b[0m that \x1b[32mwraps\x1b[0m over lines",
+		})
+	}
+	tbl.SetMaxWidth(3, 20)
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			tbl.Render()
+		}
+	})
+	b.Run("stripped", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			clear(tbl.visibleCache)
+			tbl.InvalidateLayout()
+			tbl.Render()
+		}
+	})
+}
+
+func TestStripANSIBytesMatchesStripANSI(t *testing.T) {
+	for _, s := range []string{
+		"plain text",
+		"\x1b[31mred\x1b[0m",
+		"\x1b[1;38;5;208mbold orange\x1b[0m and \x1b[4munderlined\x1b[24m",
+		"\x1b]8;;https://example.com\x07link\x1b]8;;\x07",
+		"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\",
+		"\x1b[2K\x1b[1Gcursor moves",
+		"\x1bPdevice control\x1b\\ after",
+		"\x1b7saved\x1b8",
+		"",
+	} {
+		want := StripANSI(s)
+		if got := string(StripANSIBytes([]byte(s))); got != want {
+			t.Errorf("StripANSIBytes(%q) = %q, want %q", s, got, want)
+		}
+	}
+
+	// Input without escape sequences is returned as is
+	b := []byte("plain")
+	if got := StripANSIBytes(b); &got[0] != &b[0] {
+		t.Error("StripANSIBytes copied input without escape sequences")
+	}
+}
+
+func TestWrapTextKeepsColorOnEveryLine(t *testing.T) {
+	const green = "\x1b[32m"
+	sentence := "the quick brown fox jumps over the lazy dog"
+	lines := WrapText(green+sentence+"\x1b[0m", 10, WrapWord)
+
+	var words []string
+	for _, line := range lines {
+		if w := DisplayWidth(line); w > 10 {
+			t.Errorf("line %q is %d wide, want at most 10", line, w)
+		}
+		if !strings.HasPrefix(line, green) || !strings.HasSuffix(line, "\x1b[0m") {
+			t.Errorf("line %q isn't colored green on its own", line)
+		}
+		words = append(words, strings.Fields(StripANSI(line))...)
+	}
+	if got := strings.Join(words, " "); got != sentence {
+		t.Errorf("wrapped lines hold %q, want %q", got, sentence)
+	}
+
+	// A style opened mid-text is re-opened after the break
+	lines = WrapText("the \x1b[31mquick brown\x1b[0m fox jumps", 10, WrapWord)
+	want := []string{"the \x1b[31mquick\x1b[0m", "\x1b[31mbrown\x1b[0m fox", "jumps"}
+	if !slices.Equal(lines, want) {
+		t.Errorf("WrapText() = %q, want %q", lines, want)
+	}
+}
+
+func TestStripANSIAndDisplayWidth(t *testing.T) {
+	s := "\x1b[1m\x1b[31m日本\x1b[0m \x1b[4;36mok\x1b[24m\x1b]8;;https://example.com\x07 🚀\x1b]8;;\x07"
+	if got, want := StripANSI(s), "日本 ok 🚀"; got != want {
+		t.Errorf("StripANSI() = %q, want %q", got, want)
+	}
+	// Two wide characters, a space, two letters, a space and a wide emoji
+	if got := DisplayWidth(s); got != 10 {
+		t.Errorf("DisplayWidth() = %d, want 10", got)
+	}
+	if DisplayWidth(s) != DisplayWidth(StripANSI(s)) {
+		t.Error("DisplayWidth counts escape sequences")
+	}
+}
+
+func TestSortMovesSeparatorsWithRows(t *testing.T) {
+	tbl := newTestTable("ID")
+	tbl.AddRows([][]string{{"3"}, {"1"}, {"2"}})
+	tbl.SetSeparatorBefore(0, "bold")
+	tbl.SetSeparatorBefore(2, "double")
+	tbl.SortByColumn(0, true)
+
+	want := []string{
+		"┌────┐",
+		"│ ID │",
+		"┝━━━━┥",
+		"│ 1  │",
+		"╞════╡",
+		"│ 2  │",
+		"├────┤",
+		"│ 3  │",
+		"└────┘",
+	}
+	if got := renderLines(tbl); !slices.Equal(got, want) {
+		t.Errorf("sorted table\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestStreamRowsLeavesTableUsable(t *testing.T) {
+	tbl := newTestTable("Seq", "Event")
+	tbl.AddRow([]string{"0", "queued"})
+
+	ch := make(chan []string)
+	go func() {
+		defer close(ch)
+		for i, event := range []string{"start", "a much longer event"} {
+			// The producer uses the table while it is streamed
+			tbl.AddRow([]string{strconv.Itoa(tbl.RowCount()), event})
+			tbl.Render()
+			ch <- []string{strconv.Itoa(i + 1), event}
+		}
+	}()
+	var buf bytes.Buffer
+	if err := tbl.StreamRows(context.Background(), ch, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := tbl.RowCount(); n != 3 {
+		t.Errorf("table has %d rows after the stream, want 3", n)
+	}
+	// The stream is sized from the one row present when it started
+	if got := strings.Split(buf.String(), "\n")[0]; got != "┌─────┬────────┐" {
+		t.Errorf("stream top border = %q, sized from rows added later", got)
+	}
+	if got, want := tbl.GetColumnWidths(), []int{3, 19}; !slices.Equal(got, want) {
+		t.Errorf("table column widths = %v, want %v for all its rows", got, want)
+	}
+}
+
+func TestCopiesShareOrStartTheMeasureCache(t *testing.T) {
+	tbl := newTestTable("Host", "Load")
+	tbl.AddRow([]string{"alpha", "25"})
+	if _, ok := tbl.visibleCache["alpha"]; !ok {
+		t.Fatal("added cells weren't measured")
+	}
+
+	if n := len(tbl.clone().visibleCache); n != 0 {
+		t.Errorf("derived copy started with %d measured cells, want none", n)
+	}
+	c := tbl.Clone()
+	if _, ok := c.visibleCache["alpha"]; !ok {
+		t.Error("Clone didn't keep the measured cells")
+	}
+	c.AddRow([]string{"beta", "50"})
+	if _, ok := tbl.visibleCache["beta"]; ok {
+		t.Error("Clone shares its cache with the original")
+	}
+
+	// The numbered copy drawn in the table's place measures into its cache
+	tbl.EnableRowCount(true)
+	tbl.Render()
+	tbl.prepared.visible("gamma")
+	if _, ok := tbl.visibleCache["gamma"]; !ok {
+		t.Error("prepared copy doesn't share the table's cache")
+	}
+
+	// Copies rendered under the shared lock mustn't write to one cache
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := tbl.RenderColumns([]int{1, 0}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWrapTextMatchesTableDefaults(t *testing.T) {
+	s := "tabs\tstop, lists, wrap at delimiters"
+	tbl := newTestTable("Notes")
+	tbl.SetColumnWidth(0, 12)
+	tbl.AddRow([]string{s})
+
+	lines := renderLines(tbl)
+	var cellLines []string
+	for _, line := range lines[3 : len(lines)-1] {
+		cellLines = append(cellLines, strings.TrimRight(cells(line)[0][1:], " "))
+	}
+	if len(cellLines) < 3 {
+		t.Fatalf("cell wrapped to %q, want several lines", cellLines)
+	}
+	if got := WrapText(s, 12, WrapAuto); !slices.Equal(got, cellLines) {
+		t.Errorf("WrapText() = %q, want %q as a table wraps it", got, cellLines)
+	}
+}
@@ -0,0 +1,34 @@
+package table
+
+import "fmt"
+
+// Transpose returns a new table with rows and columns swapped: its first
+// column holds t's headers, and row i of t becomes the column headed
+// "Row i+1". The border, wrapping and title settings carry over; per-column
+// and per-row settings don't. Descriptions are dropped, as they belong to
+// rows that no longer exist.
+func (t *Table) Transpose() *Table {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	headers := make([]string, len(t.Rows)+1)
+	for i := range t.Rows {
+		headers[i+1] = fmt.Sprintf("Row %d", i+1)
+	}
+
+	tt := NewTable(headers)
+	t.copyDisplaySettings(tt)
+	tt.title = t.title
+
+	for ci, h := range t.Headers {
+		row := make([]string, len(headers))
+		row[0] = h
+		for ri, r := range t.Rows {
+			if ci < len(r) {
+				row[ri+1] = r[ci]
+			}
+		}
+		tt.addRow(row)
+	}
+	return tt
+}
@@ -0,0 +1,61 @@
+package table
+
+// AddTreeRow adds a row at the given depth of a tree drawn in the first
+// column: rows below depth 0 get connectors ("├─ ", "└─ " and "│  " guides)
+// linking them to the rows above, based on the depths of the rows around
+// them. Rows added with AddRow are at depth 0.
+func (t *Table) AddTreeRow(depth int, row []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.addRow(row)
+	if depth > 0 {
+		t.treeDepths[len(t.Rows)-1] = depth
+	}
+}
+
+// treeConnectors returns the connector prefix of each data row's tree
+// column, or nil if no row is nested. The connectors fall back to ASCII
+// without ANSI support or with an ASCII grid.
+func (t *Table) treeConnectors() []string {
+	if len(t.treeDepths) == 0 {
+		return nil
+	}
+	branch, last, guide := "├─ ", "└─ ", "│  "
+	if !t.supportANSI || t.asciiGrid() {
+		branch, last, guide = "|- ", "`- ", "|  "
+	}
+
+	maxDepth := 0
+	for _, d := range t.treeDepths {
+		maxDepth = max(maxDepth, d)
+	}
+	// Walking up from the last row, more[d] reports whether a row further
+	// down continues depth d, with no shallower row in between
+	more := make([]bool, maxDepth+1)
+	prefixes := make([]string, len(t.Rows))
+	for ri := len(t.Rows) - 1; ri >= 0; ri-- {
+		d := t.treeDepths[ri]
+		if d > 0 {
+			prefix := ""
+			for level := 1; level < d; level++ {
+				if more[level] {
+					prefix += guide
+				} else {
+					prefix += "   "
+				}
+			}
+			if more[d] {
+				prefix += branch
+			} else {
+				prefix += last
+			}
+			prefixes[ri] = prefix
+		}
+		more[d] = true
+		for level := d + 1; level <= maxDepth; level++ {
+			more[level] = false
+		}
+	}
+	return prefixes
+}
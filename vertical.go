@@ -0,0 +1,41 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderVertical renders each row as a two-column "Field | Value" table,
+// pairing every header with the row's cell. Useful for single records with
+// many fields. With several rows, each block is titled "Record N" and the
+// blocks are separated by a blank line.
+func (t *Table) RenderVertical() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	blocks := make([]string, 0, len(t.Rows))
+	for ri, row := range t.Rows {
+		v := t.verticalRecord(ri, row)
+		if len(t.Rows) > 1 {
+			v.SetTitle(fmt.Sprintf("Record %d", ri+1))
+		}
+		blocks = append(blocks, v.Render())
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// verticalRecord builds the field/value table for one row, carrying over the
+// border and wrapping settings of t
+func (t *Table) verticalRecord(ri int, row []string) *Table {
+	v := NewTable([]string{"Field", "Value"})
+	t.copyDisplaySettings(v)
+
+	for ci, h := range t.Headers {
+		value := ""
+		if ci < len(row) {
+			value = t.cellDisplayValue(ri, ci, row[ci])
+		}
+		v.AddRow([]string{h, value})
+	}
+	return v
+}
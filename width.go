@@ -0,0 +1,81 @@
+package table
+
+import "unicode"
+
+// wideRanges are the code point ranges rendered two columns wide by
+// terminals (East Asian Wide and Fullwidth characters, plus emoji)
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo
+	{0x2E80, 0x303E},   // CJK Radicals .. CJK Symbols and Punctuation
+	{0x3041, 0x33FF},   // Hiragana .. CJK Compatibility
+	{0x3400, 0x4DBF},   // CJK Unified Ideographs Extension A
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs
+	{0xA000, 0xA4CF},   // Yi Syllables and Radicals
+	{0xAC00, 0xD7A3},   // Hangul Syllables
+	{0xF900, 0xFAFF},   // CJK Compatibility Ideographs
+	{0xFE30, 0xFE4F},   // CJK Compatibility Forms
+	{0xFF00, 0xFF60},   // Fullwidth Forms
+	{0xFFE0, 0xFFE6},   // Fullwidth Signs
+	{0x1F300, 0x1F64F}, // Miscellaneous Symbols and Pictographs, Emoticons
+	{0x1F680, 0x1F6FF}, // Transport and Map Symbols
+	{0x1F900, 0x1F9FF}, // Supplemental Symbols and Pictographs
+	{0x1FA70, 0x1FAFF}, // Symbols and Pictographs Extended-A
+	{0x20000, 0x2FFFD}, // CJK Unified Ideographs Extension B..
+	{0x30000, 0x3FFFD}, // CJK Unified Ideographs Extension G..
+}
+
+// runeWidth returns the number of terminal columns r occupies
+func runeWidth(r rune) int {
+	if r < 0x20 || r == 0x7F || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	for _, rng := range wideRanges {
+		if r >= rng[0] && r <= rng[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+// displayWidth returns the number of terminal columns s occupies once ANSI
+// sequences are stripped, counting wide characters as two columns
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range stripANSI(s) {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// DisplayWidth returns the number of terminal columns s occupies, ignoring
+// ANSI sequences and counting wide characters (CJK, emoji) as two, so that
+// content can be aligned consistently with the table's own
+func DisplayWidth(s string) int {
+	return displayWidth(s)
+}
+
+// visibleText is a value with its ANSI sequences stripped, along with the
+// display width of its longest line
+type visibleText struct {
+	text  string
+	width int
+}
+
+// maxVisibleCache bounds the number of values a table keeps measured, so that
+// formatters producing new values on every render don't grow it without limit
+const maxVisibleCache = 1 << 16
+
+// visible strips and measures s, caching the result: every render measures
+// the same cells again and the wrap routines strip them once more
+func (t *Table) visible(s string) visibleText {
+	if v, ok := t.visibleCache[s]; ok {
+		return v
+	}
+	v := visibleText{text: stripANSI(s)}
+	v.width = longestLineWidth(v.text)
+	if len(t.visibleCache) >= maxVisibleCache {
+		clear(t.visibleCache)
+	}
+	t.visibleCache[s] = v
+	return v
+}
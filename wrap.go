@@ -0,0 +1,23 @@
+package table
+
+// WrapText wraps s into lines of at most width display columns (see
+// DisplayWidth), the way cells are wrapped with the given wrap mode.
+// Embedded newlines are hard breaks.
+// ANSI sequences don't count towards the width: CSI prefixes and suffixes
+// wrapping the whole text are repeated on every line, and styles opened
+// inside it are re-opened at the start of the following lines and reset at
+// the end of each. A width below 1 leaves the lines unwrapped.
+func WrapText(s string, width int, mode WrapMode) []string {
+	if width < 1 {
+		mode = WrapNone
+	}
+	t := &Table{
+		columnWidths:   []int{width},
+		wrapMode:       mode,
+		wrapDelimiters: []string{defaultWrapDelimiter},
+		tabWidth:       defaultTabWidth,
+		visibleCache:   make(map[string]visibleText),
+		mu:             new(tableMutex),
+	}
+	return t.smartSplitCellContent(s, 0)
+}